@@ -0,0 +1,204 @@
+/*
+	Building matrices out of blocks, and taking them apart again.
+*/
+package linear
+
+// HStack joins matrices with the same number of rows side by side, as in [A | B | C].
+func HStack(ms ...Matrix) (Matrix, bool) {
+	if len(ms) == 0 {
+		return EmptyMatrix(), false
+	}
+	cols := 0
+	for _, m := range ms {
+		if m.IsDegenerate() || m.rows != ms[0].rows {
+			return EmptyMatrix(), false
+		}
+		cols += m.cols
+	}
+
+	result := MakeMatrix(ms[0].rows, cols)
+	for i := range result.data {
+		result.data[i] = make(MatrixRow, cols)
+		c := 0
+		for _, m := range ms {
+			copyCells(result.data[i][c:], m.data[i])
+			c += m.cols
+		}
+	}
+	return result, true
+}
+
+// VStack piles matrices with the same number of columns on top of each other.
+func VStack(ms ...Matrix) (Matrix, bool) {
+	if len(ms) == 0 {
+		return EmptyMatrix(), false
+	}
+	rows := 0
+	for _, m := range ms {
+		if m.IsDegenerate() || m.cols != ms[0].cols {
+			return EmptyMatrix(), false
+		}
+		rows += m.rows
+	}
+
+	result := MakeMatrix(rows, ms[0].cols)
+	i := 0
+	for _, m := range ms {
+		for _, row := range m.data {
+			result.data[i] = make(MatrixRow, m.cols)
+			copyCells(result.data[i], row)
+			i++
+		}
+	}
+	return result, true
+}
+
+// Block assembles a matrix from a grid of blocks, as in [[A, B], [C, D]]. The
+// blocks in each row of the grid must have the same number of rows, and every
+// row of the grid must come to the same number of columns.
+func Block(blocks [][]Matrix) (Matrix, bool) {
+	rows := make([]Matrix, len(blocks))
+	for i, blockRow := range blocks {
+		var ok bool
+		if rows[i], ok = HStack(blockRow...); !ok {
+			return EmptyMatrix(), false
+		}
+	}
+	return VStack(rows...)
+}
+
+// BlockDiag places matrices along the diagonal of a larger matrix, with zeros elsewhere.
+func BlockDiag(ms ...Matrix) (Matrix, bool) {
+	rows, cols := 0, 0
+	for _, m := range ms {
+		if m.IsDegenerate() {
+			return EmptyMatrix(), false
+		}
+		rows += m.rows
+		cols += m.cols
+	}
+
+	result := ZeroMatrix(rows, cols)
+	r, c := 0, 0
+	for _, m := range ms {
+		for i, row := range m.data {
+			copyCells(result.data[r+i][c:], row)
+		}
+		r += m.rows
+		c += m.cols
+	}
+	return result, true
+}
+
+// Submatrix copies out rows [r0, r1) and columns [c0, c1) of the matrix.
+func (m Matrix) Submatrix(r0, r1, c0, c1 int) (Matrix, bool) {
+	if m.IsDegenerate() || r0 < 0 || r0 > r1 || r1 > m.rows || c0 < 0 || c0 > c1 || c1 > m.cols {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(r1-r0, c1-c0)
+	for i := range result.data {
+		result.data[i] = make(MatrixRow, c1-c0)
+		copyCells(result.data[i], m.data[r0+i][c0:c1])
+	}
+	return result, true
+}
+
+// splitPoints turns cut positions into the boundaries of each piece, checking they are in order.
+func splitPoints(n int, at []int) ([]int, bool) {
+	bounds := append([]int{0}, at...)
+	bounds = append(bounds, n)
+	for k := 1; k < len(bounds); k++ {
+		if bounds[k] < bounds[k-1] {
+			return nil, false
+		}
+	}
+	return bounds, true
+}
+
+// SplitRows cuts the matrix above each of the given rows, undoing VStack.
+func (m Matrix) SplitRows(at ...int) ([]Matrix, bool) {
+	grid, ok := m.SplitBlocks(at, nil)
+	if !ok {
+		return nil, false
+	}
+	pieces := make([]Matrix, len(grid))
+	for k, row := range grid {
+		pieces[k] = row[0]
+	}
+	return pieces, true
+}
+
+// SplitCols cuts the matrix to the left of each of the given columns, undoing HStack.
+func (m Matrix) SplitCols(at ...int) ([]Matrix, bool) {
+	grid, ok := m.SplitBlocks(nil, at)
+	if !ok {
+		return nil, false
+	}
+	return grid[0], true
+}
+
+// SplitBlocks cuts the matrix into a grid of blocks, undoing Block.
+func (m Matrix) SplitBlocks(rowsAt, colsAt []int) ([][]Matrix, bool) {
+	rowBounds, ok := splitPoints(m.rows, rowsAt)
+	if !ok {
+		return nil, false
+	}
+	colBounds, ok := splitPoints(m.cols, colsAt)
+	if !ok {
+		return nil, false
+	}
+
+	grid := make([][]Matrix, len(rowBounds)-1)
+	for i := range grid {
+		grid[i] = make([]Matrix, len(colBounds)-1)
+		for j := range grid[i] {
+			if grid[i][j], ok = m.Submatrix(rowBounds[i], rowBounds[i+1], colBounds[j], colBounds[j+1]); !ok {
+				return nil, false
+			}
+		}
+	}
+	return grid, true
+}
+
+// SchurComplement of the block a in [[a, b], [c, d]], which is d - c * a⁻¹ * b.
+// It fails if a is singular or the blocks do not fit together.
+func SchurComplement(a, b, c, d Matrix) (Matrix, bool) {
+	if a.rows != b.rows || c.rows != d.rows || a.cols != c.cols || b.cols != d.cols {
+		return EmptyMatrix(), false
+	}
+	if !a.isInvertible() {
+		return EmptyMatrix(), false
+	}
+	aInvB, ok := a.Solve(b)
+	if !ok {
+		return EmptyMatrix(), false
+	}
+	cAInvB, _ := c.Multiply(aInvB)
+	return d.Sub(cAInvB)
+}
+
+// SolveBlockSystem solves [[a, b], [c, d]] * [x; y] = [f; g] by way of the
+// Schur complement of a, without assembling the whole matrix.
+func SolveBlockSystem(a, b, c, d, f, g Matrix) (x, y Matrix, ok bool) {
+	s, ok := SchurComplement(a, b, c, d)
+	if !ok || !s.isInvertible() || f.IsDegenerate() || g.IsDegenerate() ||
+		f.rows != a.rows || g.rows != c.rows || f.cols != g.cols {
+		return EmptyMatrix(), EmptyMatrix(), false
+	}
+	// y = s⁻¹ (g - c a⁻¹ f)
+	aInvF, _ := a.Solve(f)
+	cAInvF, _ := c.Multiply(aInvF)
+	rhs, _ := g.Sub(cAInvF)
+	y, _ = s.Solve(rhs)
+	// x = a⁻¹ (f - b y)
+	by, _ := b.Multiply(y)
+	fMinusBy, _ := f.Sub(by)
+	x, _ = a.Solve(fMinusBy)
+	return x, y, true
+}
+
+// isInvertible if the matrix is square and of full rank.
+func (m Matrix) isInvertible() bool {
+	rank, ok := m.Rank()
+	return ok && m.rows == m.cols && rank == m.rows
+}
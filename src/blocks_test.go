@@ -0,0 +1,135 @@
+package linear
+
+import (
+	"testing"
+)
+
+func TestHStackAndVStack(t *testing.T) {
+	a := unitMatrix(2)
+	b := sequenceMatrix(2, 1)
+	h, success := HStack(a, b, a)
+	if !success || h.RowCount() != 2 || h.ColCount() != 5 {
+		t.Fatal("HStack")
+	}
+
+	v, success := VStack(a, sequenceMatrix(1, 2))
+	expected := MakeMatrix(3, 2)
+	expected.AddRow(1, 0)
+	expected.AddRow(0, 1)
+	expected.AddRow(1, 2)
+	if !success || !v.Equals(expected) {
+		t.Error("VStack")
+	}
+}
+
+func TestStackingMismatchedMatricesFails(t *testing.T) {
+	if _, success := HStack(unitMatrix(2), unitMatrix(3)); success {
+		t.Error("HStack")
+	}
+	if _, success := VStack(unitMatrix(2), unitMatrix(3)); success {
+		t.Error("VStack")
+	}
+	if _, success := VStack(); success {
+		t.Error("VStack of nothing")
+	}
+}
+
+func TestBlockAssemblesGrid(t *testing.T) {
+	m, success := Block([][]Matrix{
+		{unitMatrix(2), ZeroMatrix(2, 1)},
+		{ZeroMatrix(1, 2), unitMatrix(1)},
+	})
+	if !success || !m.Equals(unitMatrix(3)) {
+		t.Fail()
+	}
+}
+
+func TestBlockDiag(t *testing.T) {
+	m, success := BlockDiag(unitMatrix(1), unitMatrix(2), unitMatrix(1))
+	if !success || !m.Equals(unitMatrix(4)) {
+		t.Fail()
+	}
+}
+
+func TestSplitBlocksUndoesBlock(t *testing.T) {
+	m := sequenceMatrix(4, 5)
+	grid, success := m.SplitBlocks([]int{1}, []int{2, 4})
+	if !success || len(grid) != 2 || len(grid[0]) != 3 {
+		t.Fatal("SplitBlocks")
+	}
+	Fail(t).If(intsAreNotEqual(3, grid[1][1].RowCount()))
+	Fail(t).If(intsAreNotEqual(1, grid[1][2].ColCount()))
+
+	rebuilt, _ := Block(grid)
+	if !rebuilt.Equals(m) {
+		t.Fail()
+	}
+}
+
+func TestSplitRowsAndCols(t *testing.T) {
+	m := sequenceMatrix(3, 3)
+	rows, _ := m.SplitRows(1)
+	rebuilt, _ := VStack(rows...)
+	if len(rows) != 2 || !rebuilt.Equals(m) {
+		t.Error("SplitRows")
+	}
+	cols, _ := m.SplitCols(0, 2)
+	rebuilt, _ = HStack(cols[1:]...)
+	if len(cols) != 3 || cols[0].ColCount() != 0 || !rebuilt.Equals(m) {
+		t.Error("SplitCols")
+	}
+}
+
+func TestSplitOutOfOrderFails(t *testing.T) {
+	if _, success := sequenceMatrix(3, 3).SplitRows(2, 1); success {
+		t.Fail()
+	}
+}
+
+func TestSchurComplement(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 1, 1)
+	m.AddRow(4, 3, 3)
+	m.AddRow(8, 7, 9)
+	grid, _ := m.SplitBlocks([]int{1}, []int{1})
+
+	s, success := SchurComplement(grid[0][0], grid[0][1], grid[1][0], grid[1][1])
+	expected := MakeMatrix(2, 2)
+	expected.AddRow(1, 1)
+	expected.AddRow(3, 5)
+	if !success || !s.Equals(expected) {
+		t.Fail()
+	}
+	// det(M) = det(A) det(S)
+	detM, _ := m.Det()
+	detS, _ := s.Det()
+	Fail(t).If(rationalsAreNotEqual(detM, detS.Mul(detS, grid[0][0].data[0][0])))
+}
+
+func TestSchurComplementOfSingularBlockFails(t *testing.T) {
+	z := ZeroMatrix(2, 2)
+	if _, success := SchurComplement(z, z, z, z); success {
+		t.Fail()
+	}
+}
+
+func TestSolveBlockSystemMatchesSolve(t *testing.T) {
+	m := MakeMatrix(4, 4)
+	m.AddRow(4, 1, 0, 2)
+	m.AddRow(1, 3, 1, 0)
+	m.AddRow(0, 1, 5, 1)
+	m.AddRow(2, 0, 1, 6)
+	rhs := sequenceMatrix(4, 1)
+
+	blocks, _ := m.SplitBlocks([]int{2}, []int{2})
+	parts, _ := rhs.SplitRows(2)
+	x, y, success := SolveBlockSystem(blocks[0][0], blocks[0][1], blocks[1][0], blocks[1][1], parts[0], parts[1])
+	if !success {
+		t.Fatal("SolveBlockSystem failed")
+	}
+	actual, _ := VStack(x, y)
+	expected, _ := m.Solve(rhs)
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
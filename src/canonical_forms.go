@@ -0,0 +1,258 @@
+/*
+	Jordan and rational canonical forms of square matrices.
+*/
+package linear
+
+import . "big"
+
+// JordanForm is a similarity m = P * J * P⁻¹ in which J is block diagonal
+// with a Jordan block for each chain of generalized eigenvectors: the
+// eigenvalue on the diagonal and ones just above it. The columns of P are the
+// chains, each starting from an eigenvector.
+type JordanForm struct {
+	J, P Matrix
+}
+
+// FrobeniusForm is a similarity m = P * F * P⁻¹ in which F is block diagonal
+// with the companion matrices of the invariant factors of m, each dividing
+// the next. Unlike the Jordan form it exists for every rational matrix.
+type FrobeniusForm struct {
+	F, P Matrix
+}
+
+// CompanionMatrix of a polynomial of degree n >= 1 is the n x n matrix with
+// ones below the diagonal and the negated coefficients of the monic
+// polynomial in its last column. Its characteristic and minimal polynomials
+// are both the monic polynomial.
+func CompanionMatrix(p Polynomial) (Matrix, bool) {
+	n := p.Degree()
+	if n < 1 {
+		return EmptyMatrix(), false
+	}
+	p = p.Monic()
+	c := ZeroMatrix(n, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			c.data[i][i-1].SetInt64(1)
+		}
+		c.data[i][n-1].Neg(p[i])
+	}
+	return c, true
+}
+
+// fromColumns builds a matrix with the given vectors as its columns.
+func fromColumns(vs []Vector) Matrix {
+	cols := make([]Matrix, len(vs))
+	for j, v := range vs {
+		cols[j] = v.ColMatrix()
+	}
+	m, _ := HStack(cols...)
+	return m
+}
+
+// extendsBasis if v is independent of the independent vectors in basis.
+func extendsBasis(basis []Vector, v Vector) bool {
+	rank, _ := fromColumns(append(basis[:len(basis):len(basis)], v)).Rank()
+	return rank > len(basis)
+}
+
+func (m Matrix) apply(v Vector) Vector {
+	product, _ := m.Multiply(v.ColMatrix())
+	result, _ := product.Col(0)
+	return result
+}
+
+// JordanForm computes the Jordan normal form of a square matrix whose
+// eigenvalues are all rational. For each eigenvalue λ, with N = m - λI, the
+// chains are built from the longest down: at each length k, vectors are
+// taken from the null space of N^k which are independent of the null space
+// of N^(k-1) and of the longer chains, and then repeatedly multiplied by N.
+func (m Matrix) JordanForm() (JordanForm, bool) {
+	e, ok := m.Eigen()
+	if !ok || e.Path != EigenExact {
+		return JordanForm{}, false
+	}
+	var columns []Vector
+	j := ZeroMatrix(m.rows, m.cols)
+	for _, space := range e.Spaces {
+		shift, _ := IdentityMatrix(m.rows).Scale(space.Value)
+		n, _ := m.Sub(shift)
+
+		// kernels[k] is a basis of the null space of N^k.
+		kernels := [][]Vector{{}}
+		power := IdentityMatrix(m.rows)
+		for len(kernels[len(kernels)-1]) < space.Multiplicity {
+			power, _ = power.Multiply(n)
+			kernel, _ := power.NullSpace()
+			kernels = append(kernels, kernel)
+		}
+
+		var chains [][]Vector
+		var level []Vector // the vectors of the longer chains at the current length
+		for k := len(kernels) - 1; k >= 1; k-- {
+			span := append([]Vector{}, kernels[k-1]...)
+			span = append(span, level...)
+			for _, v := range kernels[k] {
+				if len(span) == len(kernels[k]) {
+					break
+				}
+				if extendsBasis(span, v) {
+					span = append(span, v)
+					level = append(level, v)
+					chains = append(chains, make([]Vector, k))
+				}
+			}
+			// Fill each chain from the end, so it starts with an eigenvector.
+			for i, v := range level {
+				chains[i][k-1] = v
+				level[i] = n.apply(v)
+			}
+		}
+
+		for _, chain := range chains {
+			start := len(columns)
+			for i, v := range chain {
+				j.data[start+i][start+i].Set(space.Value)
+				if i > 0 {
+					j.data[start+i-1][start+i].SetInt64(1)
+				}
+				columns = append(columns, v)
+			}
+		}
+	}
+	return JordanForm{j, fromColumns(columns)}, true
+}
+
+// cyclicDecomposition splits the space into cyclic subspaces, one for each
+// nonconstant invariant factor of m. The invariant factors are the monic
+// polynomials on the diagonal of the Smith normal form U * (xI - m) * V over
+// Q[x], each dividing the next. The reduction mirrors SmithNormalForm, with
+// degree in place of absolute value, and keeps track of U⁻¹ as it goes.
+// Column i of U⁻¹ is a vector of polynomials p(x) = Σ x^k p_k, and the vector
+// Σ m^k p_k it stands for generates the cyclic subspace of the i-th factor.
+func (m Matrix) cyclicDecomposition() (factors []Polynomial, generators []Vector) {
+	n := m.rows
+	a := make([][]Polynomial, n)
+	inv := make([][]Polynomial, n)
+	for i := range a {
+		a[i] = make([]Polynomial, n)
+		inv[i] = make([]Polynomial, n)
+		for j := range a[i] {
+			a[i][j] = Polynomial{new(Rat).Neg(cellOrZero(m.data[i][j]))}
+			inv[i][j] = Polynomial{}
+			if i == j {
+				a[i][j] = append(a[i][j], NewRat(1, 1))
+				inv[i][j] = NewPolynomial(1)
+			}
+			a[i][j] = a[i][j].trim()
+		}
+	}
+	// Taking q times row src from row dst multiplies U on the left by
+	// I - q e_dst e_src', so U⁻¹ gains q times its column dst in column src.
+	subtractRow := func(dst, src int, q Polynomial) {
+		for j := range a[dst] {
+			a[dst][j] = a[dst][j].Sub(q.Mul(a[src][j]))
+		}
+		for i := range inv {
+			inv[i][src] = inv[i][src].Add(q.Mul(inv[i][dst]))
+		}
+	}
+	subtractCol := func(dst, src int, q Polynomial) {
+		for i := range a {
+			a[i][dst] = a[i][dst].Sub(q.Mul(a[i][src]))
+		}
+	}
+
+	for t := 0; t < n; t++ {
+		for {
+			pi, pj := -1, -1
+			for i := t; i < n; i++ {
+				for j := t; j < n; j++ {
+					if d := a[i][j].Degree(); d >= 0 && (pi < 0 || d < a[pi][pj].Degree()) {
+						pi, pj = i, j
+					}
+				}
+			}
+			a[pi], a[t] = a[t], a[pi]
+			for i := range a {
+				a[i][pj], a[i][t] = a[i][t], a[i][pj]
+				inv[i][pi], inv[i][t] = inv[i][t], inv[i][pi]
+			}
+
+			clean := true
+			for i := t + 1; i < n; i++ {
+				q, _, _ := a[i][t].DivMod(a[t][t])
+				subtractRow(i, t, q)
+				clean = clean && a[i][t].Degree() < 0
+			}
+			for j := t + 1; j < n; j++ {
+				q, _, _ := a[t][j].DivMod(a[t][t])
+				subtractCol(j, t, q)
+				clean = clean && a[t][j].Degree() < 0
+			}
+			if !clean {
+				continue
+			}
+
+			offender := -1
+			for i := t + 1; i < n && offender < 0; i++ {
+				for j := t + 1; j < n; j++ {
+					if _, r, _ := a[i][j].DivMod(a[t][t]); r.Degree() >= 0 {
+						offender = i
+						break
+					}
+				}
+			}
+			if offender < 0 {
+				break
+			}
+			subtractRow(t, offender, NewPolynomial(-1))
+		}
+	}
+
+	for t := 0; t < n; t++ {
+		if a[t][t].Degree() < 1 {
+			continue
+		}
+		factors = append(factors, a[t][t].Monic())
+		// Horner's rule on the polynomial vector in column t of U⁻¹.
+		degree := 0
+		for i := range inv {
+			degree = max(degree, inv[i][t].Degree())
+		}
+		v := ZeroVector(n)
+		for k := degree; k >= 0; k-- {
+			v = m.apply(v)
+			for i := range inv {
+				v[i] = new(Rat).Add(v[i], inv[i][t].Coefficient(k))
+			}
+		}
+		generators = append(generators, v)
+	}
+	return factors, generators
+}
+
+// FrobeniusForm computes the rational canonical form of a square matrix.
+// Each invariant factor f of degree d has a generator v of its cyclic
+// subspace, and contributes the columns v, m * v, ..., m^(d-1) * v to P, on
+// which m acts as the companion matrix of f. The polynomial Smith form takes
+// O(n³) polynomial operations, with degrees bounded by n.
+func (m Matrix) FrobeniusForm() (FrobeniusForm, bool) {
+	if m.IsDegenerate() || m.rows != m.cols || m.rows == 0 {
+		return FrobeniusForm{}, false
+	}
+	factors, generators := m.cyclicDecomposition()
+	var blocks []Matrix
+	var columns []Vector
+	for k, f := range factors {
+		c, _ := CompanionMatrix(f)
+		blocks = append(blocks, c)
+		v := generators[k]
+		for i := 0; i < f.Degree(); i++ {
+			columns = append(columns, v)
+			v = m.apply(v)
+		}
+	}
+	f, _ := BlockDiag(blocks...)
+	return FrobeniusForm{f, fromColumns(columns)}, true
+}
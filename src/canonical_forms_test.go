@@ -0,0 +1,193 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func assertSimilar(t *testing.T, m, p, j Matrix) {
+	if !p.isInvertible() {
+		t.Fatal("P should be invertible")
+	}
+	mp, _ := m.Multiply(p)
+	pj, _ := p.Multiply(j)
+	if !mp.Equals(pj) {
+		p.Print("P = ")
+		j.Print("J = ")
+		t.Error("m * P should equal P * J")
+	}
+}
+
+func TestCompanionMatrix(t *testing.T) {
+	p := NewPolynomial(6, -5, 2) // 2x^2 - 5x + 6
+	c, success := CompanionMatrix(p)
+	if !success {
+		t.Fatal("CompanionMatrix failed")
+	}
+	expected := MakeMatrix(2, 2)
+	expected.SetCell(0, 0, 0)
+	expected.SetCell(0, 1, -3)
+	expected.SetCell(1, 0, 1)
+	expected.SetCell(1, 1, NewRat(5, 2))
+	if !c.Equals(expected) {
+		c.Print("C = ")
+		t.Error("Wrong companion matrix")
+	}
+	charPoly, _ := c.CharPoly()
+	if !charPoly.Equals(p.Monic()) {
+		t.Errorf("Expected %v; Actual %v", p.Monic(), charPoly)
+	}
+	if _, success := CompanionMatrix(NewPolynomial(3)); success {
+		t.Error("Constant polynomial has no companion matrix")
+	}
+}
+
+func TestJordanFormOfDefectiveMatrix(t *testing.T) {
+	m := MakeMatrix(4, 4)
+	m.AddRow(5, 4, 2, 1)
+	m.AddRow(0, 1, -1, -1)
+	m.AddRow(-1, -1, 3, 0)
+	m.AddRow(1, 1, -1, 2)
+	f, success := m.JordanForm()
+	if !success {
+		t.Fatal("JordanForm failed")
+	}
+	expected := MakeMatrix(4, 4)
+	expected.AddRow(1, 0, 0, 0)
+	expected.AddRow(0, 2, 0, 0)
+	expected.AddRow(0, 0, 4, 1)
+	expected.AddRow(0, 0, 0, 4)
+	if !f.J.Equals(expected) {
+		f.J.Print("J = ")
+		t.Error("Wrong Jordan form")
+	}
+	assertSimilar(t, m, f.P, f.J)
+}
+
+func TestJordanFormWithSeveralBlocksForOneEigenvalue(t *testing.T) {
+	j := MakeMatrix(5, 5)
+	j.AddRow(3, 1, 0, 0, 0)
+	j.AddRow(0, 3, 1, 0, 0)
+	j.AddRow(0, 0, 3, 0, 0)
+	j.AddRow(0, 0, 0, 3, 1)
+	j.AddRow(0, 0, 0, 0, 3)
+	// Disguise j by a change of basis.
+	p := MakeMatrix(5, 5)
+	p.AddRow(1, 1, 0, 0, 2)
+	p.AddRow(0, 1, 1, 0, 0)
+	p.AddRow(0, 0, 1, 1, 0)
+	p.AddRow(1, 0, 0, 2, 1)
+	p.AddRow(0, 0, 0, 0, 1)
+	pInv, _ := p.Inverse()
+	pj, _ := p.Multiply(j)
+	m, _ := pj.Multiply(pInv)
+
+	f, success := m.JordanForm()
+	if !success {
+		t.Fatal("JordanForm failed")
+	}
+	if !f.J.Equals(j) {
+		f.J.Print("J = ")
+		t.Error("Wrong Jordan form")
+	}
+	assertSimilar(t, m, f.P, f.J)
+}
+
+func TestJordanFormFailsForIrrationalEigenvalues(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(0, 2)
+	m.AddRow(1, 0)
+	if _, success := m.JordanForm(); success {
+		t.Error("Eigenvalues are ±√2")
+	}
+}
+
+func TestFrobeniusForm(t *testing.T) {
+	// Invariant factors x - 2 and (x - 2)(x^2 - 2).
+	m := MakeMatrix(4, 4)
+	m.AddRow(2, 0, 0, 0)
+	m.AddRow(0, 2, 0, 0)
+	m.AddRow(0, 0, 0, 2)
+	m.AddRow(0, 0, 1, 0)
+	p := MakeMatrix(4, 4)
+	p.AddRow(1, 2, 0, 1)
+	p.AddRow(0, 1, 3, 0)
+	p.AddRow(1, 0, 1, 0)
+	p.AddRow(0, 1, 0, 1)
+	pInv, _ := p.Inverse()
+	pm, _ := p.Multiply(m)
+	m, _ = pm.Multiply(pInv)
+
+	f, success := m.FrobeniusForm()
+	if !success {
+		t.Fatal("FrobeniusForm failed")
+	}
+	first, _ := CompanionMatrix(NewPolynomial(-2, 1))
+	second, _ := CompanionMatrix(NewPolynomial(-2, 1).Mul(NewPolynomial(-2, 0, 1)))
+	expected, _ := BlockDiag(first, second)
+	if !f.F.Equals(expected) {
+		f.F.Print("F = ")
+		t.Error("Wrong rational canonical form")
+	}
+	assertSimilar(t, m, f.P, f.F)
+}
+
+func TestFrobeniusFormOfCyclicMatrixIsCompanion(t *testing.T) {
+	m := fractionMatrix(3, 3)
+	f, success := m.FrobeniusForm()
+	if !success {
+		t.Fatal("FrobeniusForm failed")
+	}
+	charPoly, _ := m.CharPoly()
+	expected, _ := CompanionMatrix(charPoly)
+	if !f.F.Equals(expected) {
+		f.F.Print("F = ")
+		t.Error("Expected the companion matrix of the characteristic polynomial")
+	}
+	assertSimilar(t, m, f.P, f.F)
+}
+
+func TestFrobeniusFormOfScalarMatrix(t *testing.T) {
+	m, _ := IdentityMatrix(4).Scale(NewRat(3, 1))
+	f, success := m.FrobeniusForm()
+	if !success {
+		t.Fatal("FrobeniusForm failed")
+	}
+	if !f.F.Equals(m) {
+		f.F.Print("F = ")
+		t.Error("A scalar matrix is its own rational canonical form")
+	}
+	assertSimilar(t, m, f.P, f.F)
+}
+
+func TestFrobeniusFormOfDerogatoryMatrix(t *testing.T) {
+	// Invariant factors x^2 + 1, x^2 + 1 and (x^2 + 1)(x - 1), conjugated
+	// by a unimodular matrix so that no cell is left at zero.
+	rotation := MakeMatrix(2, 2)
+	rotation.AddRow(0, -1)
+	rotation.AddRow(1, 0)
+	last, _ := CompanionMatrix(NewPolynomial(1, 0, 1).Mul(NewPolynomial(-1, 1)))
+	m, _ := BlockDiag(rotation, rotation, last)
+	p := unitMatrix(7)
+	for i := 0; i < 7; i++ {
+		for j := i + 1; j < 7; j++ {
+			p.SetCell(i, j, i+j)
+		}
+	}
+	pInv, _ := p.Inverse()
+	pm, _ := p.Multiply(m)
+	m, _ = pm.Multiply(pInv)
+
+	f, success := m.FrobeniusForm()
+	if !success {
+		t.Fatal("FrobeniusForm failed")
+	}
+	first, _ := CompanionMatrix(NewPolynomial(1, 0, 1))
+	expected, _ := BlockDiag(first, first, last)
+	if !f.F.Equals(expected) {
+		f.F.Print("F = ")
+		t.Error("Wrong rational canonical form")
+	}
+	assertSimilar(t, m, f.P, f.F)
+}
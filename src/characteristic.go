@@ -0,0 +1,70 @@
+/*
+	Characteristic and minimal polynomials of square matrices.
+*/
+package linear
+
+import . "big"
+
+// Trace is the sum of the diagonal entries of a square matrix.
+func (m Matrix) Trace() (*Rat, bool) {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return nil, false
+	}
+	trace := new(Rat)
+	for _, d := range m.Diagonal() {
+		trace.Add(trace, cellOrZero(d))
+	}
+	return trace, true
+}
+
+// CharPoly computes the characteristic polynomial det(xI - m) of a square
+// matrix by the Faddeev-LeVerrier recurrence, which needs no divisions by
+// matrix entries and so stays exact:
+//
+//	M(0) = 0, c(n) = 1
+//	M(k) = m M(k-1) + c(n-k+1) I
+//	c(n-k) = -tr(m M(k)) / k
+func (m Matrix) CharPoly() (Polynomial, bool) {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return nil, false
+	}
+	n := m.rows
+	coeffs := make(Polynomial, n+1)
+	coeffs[n] = NewRat(1, 1)
+	// product holds m * M(k-1), which is both the trace term for the previous
+	// coefficient and the start of the next M(k).
+	product := ZeroMatrix(n, n)
+	for k := 1; k <= n; k++ {
+		shift, _ := IdentityMatrix(n).Scale(coeffs[n-k+1])
+		mk, _ := product.Add(shift)
+		product, _ = m.Multiply(mk)
+		trace, _ := product.Trace()
+		coeffs[n-k] = trace.Quo(trace, NewRat(int64(-k), 1))
+	}
+	return coeffs, true
+}
+
+// MinimalPoly computes the monic polynomial of least degree that vanishes
+// at the matrix. Successive powers of the matrix are flattened into vectors
+// until one is a combination of those before it; the coefficients of that
+// combination give the polynomial.
+func (m Matrix) MinimalPoly() (Polynomial, bool) {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return nil, false
+	}
+	power := IdentityMatrix(m.rows)
+	powers, _ := power.Vec()
+	for k := 1; ; k++ {
+		power, _ = power.Multiply(m)
+		next, _ := power.Vec()
+		if coeffs, ok := powers.Solve(next); ok {
+			p := make(Polynomial, k+1)
+			for i := 0; i < k; i++ {
+				p[i] = new(Rat).Neg(coeffs.data[i][0])
+			}
+			p[k] = NewRat(1, 1)
+			return p, true
+		}
+		powers, _ = HStack(powers, next)
+	}
+}
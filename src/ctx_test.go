@@ -0,0 +1,79 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestCtxVariantsSucceedWithLiveContext(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	ctx := context.Background()
+	if _, err := m.MultiplyCtx(ctx, m); err != nil {
+		t.Error(err)
+	}
+	if _, err := m.AfterGaussJordanEliminationCtx(ctx); err != nil {
+		t.Error(err)
+	}
+	if _, err := m.DetCtx(ctx); err != nil {
+		t.Error(err)
+	}
+	if _, err := m.InverseCtx(ctx); err != nil {
+		t.Error(err)
+	}
+	if _, err := m.SolveCtx(ctx, unitMatrix(4)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultiplyCtxReportsProgressWhenCanceled(t *testing.T) {
+	m := fractionMatrix(70, 70)
+	_, err := m.MultiplyCtx(canceledContext(), m)
+	var canceled *CanceledError
+	if !errors.As(err, &canceled) {
+		t.Fatalf("Expected a *CanceledError; Actual %v", err)
+	}
+	Fail(t).If(intsAreNotEqual(9, canceled.Total))
+	Fail(t).If(intsAreNotEqual(0, canceled.Done))
+	if !errors.Is(err, context.Canceled) {
+		t.Error("CanceledError should unwrap to context.Canceled")
+	}
+}
+
+func TestEliminationCtxReportsProgressWhenCanceled(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	ctx := canceledContext()
+	for _, op := range []func() error{
+		func() error { _, err := m.AfterGaussJordanEliminationCtx(ctx); return err },
+		func() error { _, err := m.DetCtx(ctx); return err },
+		func() error { _, err := m.InverseCtx(ctx); return err },
+		func() error { _, err := m.SolveCtx(ctx, unitMatrix(4)); return err },
+	} {
+		var canceled *CanceledError
+		if err := op(); !errors.As(err, &canceled) {
+			t.Errorf("Expected a *CanceledError; Actual %v", err)
+		}
+	}
+}
+
+func TestCtxVariantsReportWhyTheyFailed(t *testing.T) {
+	ctx := context.Background()
+	if _, err := MakeMatrix(2, 2).DetCtx(ctx); err != ErrDegenerate {
+		t.Errorf("Expected ErrDegenerate; Actual %v", err)
+	}
+	if _, err := ZeroMatrix(2, 3).InverseCtx(ctx); err != ErrDimensionMismatch {
+		t.Errorf("Expected ErrDimensionMismatch; Actual %v", err)
+	}
+	if _, err := ZeroMatrix(2, 2).InverseCtx(ctx); err != ErrSingular {
+		t.Errorf("Expected ErrSingular; Actual %v", err)
+	}
+	if _, err := ZeroMatrix(2, 2).SolveCtx(ctx, unitMatrix(2)); err != ErrInconsistent {
+		t.Errorf("Expected ErrInconsistent; Actual %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+	Eigenvalues and eigenvectors of square matrices.
+*/
+package linear
+
+import . "big"
+
+// EigenPath records how an eigen-decomposition was computed.
+type EigenPath int
+
+const (
+	// EigenExact means every root of the characteristic polynomial is
+	// rational, so the eigenvalues and eigenvectors are exact.
+	EigenExact EigenPath = iota
+	// EigenFloat means some eigenvalue is irrational or complex, so the
+	// eigenvalues were approximated in floating point by the QR algorithm.
+	EigenFloat
+)
+
+func (p EigenPath) String() string {
+	if p == EigenExact {
+		return "exact"
+	}
+	return "float"
+}
+
+// Eigenspace is an exact eigenvalue with its multiplicity as a root of the
+// characteristic polynomial and a basis of its eigenvectors. The matrix is
+// diagonalizable when every eigenvalue has as many vectors as its multiplicity.
+type Eigenspace struct {
+	Value        *Rat
+	Multiplicity int
+	Vectors      []Vector
+}
+
+// Eigen is an eigen-decomposition. Values lists the eigenvalues, repeated
+// by multiplicity, whichever path was taken; Spaces is only filled in on the
+// exact path.
+type Eigen struct {
+	Path   EigenPath
+	Values []complex128
+	Spaces []Eigenspace
+}
+
+// IsDiagonalizable if the eigenvectors of an exact decomposition span the whole space.
+func (e Eigen) IsDiagonalizable() bool {
+	if e.Path != EigenExact {
+		return false
+	}
+	for _, s := range e.Spaces {
+		if len(s.Vectors) != s.Multiplicity {
+			return false
+		}
+	}
+	return true
+}
+
+// Eigen decomposes a square matrix. When its characteristic polynomial
+// splits into rational linear factors each eigenvalue is found exactly,
+// with its eigenvectors taken from the null space of m - λI. Otherwise the
+// eigenvalues, some possibly complex, are approximated from the float64
+// form of the matrix and no eigenvectors are given.
+func (m Matrix) Eigen() (Eigen, bool) {
+	charPoly, ok := m.CharPoly()
+	if !ok {
+		return Eigen{}, false
+	}
+	roots := charPoly.RationalRoots()
+	found := 0
+	for _, root := range roots {
+		found += root.Multiplicity
+	}
+
+	if found < m.rows {
+		f, _ := m.Float()
+		values, ok := f.Eigenvalues()
+		if !ok {
+			return Eigen{}, false
+		}
+		return Eigen{Path: EigenFloat, Values: values}, true
+	}
+
+	e := Eigen{Path: EigenExact}
+	for _, root := range roots {
+		shift, _ := IdentityMatrix(m.rows).Scale(root.Value)
+		shifted, _ := m.Sub(shift)
+		vectors, _ := shifted.NullSpace()
+		e.Spaces = append(e.Spaces, Eigenspace{root.Value, root.Multiplicity, vectors})
+		value, _ := root.Value.Float64()
+		for i := 0; i < root.Multiplicity; i++ {
+			e.Values = append(e.Values, complex(value, 0))
+		}
+	}
+	return e, true
+}
@@ -0,0 +1,121 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+import . "big"
+
+func TestEigenOfDiagonalizableMatrixIsExact(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 1, 1)
+	m.AddRow(1, 2, 1)
+	m.AddRow(1, 1, 2)
+	e, success := m.Eigen()
+	if !success {
+		t.Fatal("Eigen failed")
+	}
+	if e.Path != EigenExact {
+		t.Fatalf("Expected exact path; Actual %v", e.Path)
+	}
+	Fail(t).If(intsAreNotEqual(2, len(e.Spaces)))
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), e.Spaces[0].Value))
+	Fail(t).If(intsAreNotEqual(2, e.Spaces[0].Multiplicity))
+	Fail(t).If(rationalsAreNotEqual(NewRat(4, 1), e.Spaces[1].Value))
+	assertEigenvalues(t, []complex128{1, 1, 4}, e.Values)
+	if !e.IsDiagonalizable() {
+		t.Error("Symmetric matrix should be diagonalizable")
+	}
+	for _, s := range e.Spaces {
+		for _, v := range s.Vectors {
+			mv, _ := m.Multiply(v.ColMatrix())
+			if !mv.Equals(v.Scale(s.Value).ColMatrix()) {
+				t.Errorf("%v is not an eigenvector for %v", v, s.Value)
+			}
+		}
+	}
+}
+
+func TestEigenOfDefectiveMatrix(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(3, 1)
+	m.AddRow(0, 3)
+	e, _ := m.Eigen()
+	if e.Path != EigenExact || len(e.Spaces) != 1 {
+		t.Fatalf("Expected one exact eigenvalue; Actual %v", e)
+	}
+	Fail(t).If(intsAreNotEqual(1, len(e.Spaces[0].Vectors)))
+	if e.IsDiagonalizable() {
+		t.Error("Jordan block should not be diagonalizable")
+	}
+}
+
+func TestEigenFallsBackToFloatForIrrationalEigenvalues(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 1)
+	m.AddRow(1, 0)
+	e, success := m.Eigen()
+	if !success || e.Path != EigenFloat {
+		t.Fatalf("Expected float path; Actual %v", e.Path)
+	}
+	phi := (1 + math.Sqrt(5)) / 2
+	assertEigenvalues(t, []complex128{complex(1-phi, 0), complex(phi, 0)}, e.Values)
+	if e.Spaces != nil {
+		t.Error("Float path should not give eigenspaces")
+	}
+}
+
+func TestEigenFailsForNonSquareMatrix(t *testing.T) {
+	if _, success := nonZeroMatrix(2, 3).Eigen(); success {
+		t.Error("Eigen should fail")
+	}
+}
+
+func largeDeterminantMatrix() Matrix {
+	m := MakeMatrix(3, 3)
+	m.AddRow(1000003, 7, 0)
+	m.AddRow(2, 999983, 5)
+	m.AddRow(0, 3, 1000033)
+	return m
+}
+
+func TestEigenWithLargeDeterminant(t *testing.T) {
+	// The determinant is about 10^18, far too many candidates to try every
+	// divisor, and none of the eigenvalues is rational.
+	m := largeDeterminantMatrix()
+	p, _ := m.CharPoly()
+	if roots := p.RationalRoots(); len(roots) != 0 {
+		t.Errorf("Expected no rational roots; Actual %v", roots)
+	}
+	e, success := m.Eigen()
+	if !success || e.Path != EigenFloat || len(e.Values) != 3 {
+		t.Fatalf("Expected 3 eigenvalues by the float path; Actual %v by %v", e.Values, e.Path)
+	}
+	sum := e.Values[0] + e.Values[1] + e.Values[2]
+	if math.Abs(real(sum)-3000019) > 1e-6 {
+		t.Errorf("Eigenvalues should add up to the trace; Actual %v", sum)
+	}
+}
+
+func TestEigenWithLargeRationalEigenvalues(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(1000003, 7, 1)
+	m.AddRow(0, 999983, 5)
+	m.AddRow(0, 0, 1000033)
+	e, success := m.Eigen()
+	if !success || e.Path != EigenExact {
+		t.Fatalf("Expected exact path; Actual %v", e.Path)
+	}
+	Fail(t).If(intsAreNotEqual(3, len(e.Spaces)))
+	for i, expected := range []int64{999983, 1000003, 1000033} {
+		Fail(t).If(rationalsAreNotEqual(NewRat(expected, 1), e.Spaces[i].Value))
+	}
+}
+
+func BenchmarkEigenWithLargeDeterminant(b *testing.B) {
+	m := largeDeterminantMatrix()
+	for i := 0; i < b.N; i++ {
+		m.Eigen()
+	}
+}
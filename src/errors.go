@@ -0,0 +1,36 @@
+/*
+	Errors reported by the context-aware operations.
+*/
+package linear
+
+import (
+	"errors"
+	"fmt"
+)
+
+// These are the failures the Ctx variants of the matrix operations report
+// where the plain operations just return false.
+var (
+	ErrDegenerate        = errors.New("linear: matrix is not completely filled in")
+	ErrDimensionMismatch = errors.New("linear: matrix dimensions do not match")
+	ErrSingular          = errors.New("linear: matrix is singular")
+	ErrInconsistent      = errors.New("linear: system has no solution")
+)
+
+// CanceledError reports that an operation stopped because its context was
+// done, and how much of the work had been completed. Done and Total count
+// blocks of the product for multiplication, and columns eliminated otherwise.
+type CanceledError struct {
+	Op          string
+	Done, Total int
+	Err         error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("linear: %s canceled after %d of %d steps: %v", e.Op, e.Done, e.Total, e.Err)
+}
+
+// Unwrap returns the context's error, so errors.Is(err, context.Canceled) works.
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
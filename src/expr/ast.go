@@ -0,0 +1,74 @@
+/*
+	Package expr parses and evaluates matrix expressions such as
+	"A*B'", "det(A)", "A \ b" and "rref([A|b])".
+*/
+package expr
+
+import . "big"
+
+// Node is an element of a parsed expression.
+type Node interface {
+	// Pos is the byte offset of the node in the source.
+	Pos() int
+	// String is the source text the node was parsed from.
+	String() string
+}
+
+type span struct {
+	pos  int
+	text string
+}
+
+func (s span) Pos() int {
+	return s.pos
+}
+
+func (s span) String() string {
+	return s.text
+}
+
+// Number is a rational literal.
+type Number struct {
+	span
+	Value *Rat
+}
+
+// Ident names a matrix in the environment.
+type Ident struct {
+	span
+	Name string
+}
+
+// Unary is a prefix negation.
+type Unary struct {
+	span
+	Op byte
+	X  Node
+}
+
+// Binary is one of + - * / or \ applied to two operands.
+type Binary struct {
+	span
+	Op   byte
+	X, Y Node
+}
+
+// Transpose is a postfix ' applied to an operand.
+type Transpose struct {
+	span
+	X Node
+}
+
+// Call applies one of the built in functions (det, inv, rref) to an argument.
+type Call struct {
+	span
+	Func string
+	Arg  Node
+}
+
+// Concat assembles a matrix from blocks: side by side within a row, as in
+// [A|b] or [A, B], and rows separated by semicolons, as in [A, B; C, D].
+type Concat struct {
+	span
+	Rows [][]Node
+}
@@ -0,0 +1,182 @@
+/*
+	Dimension checking of parsed expressions.
+*/
+package expr
+
+import (
+	"fmt"
+	"linear"
+)
+
+// Env binds names to the matrices an expression refers to.
+type Env map[string]linear.Matrix
+
+// Error reports a problem with an expression and the subexpression responsible for it.
+type Error struct {
+	Pos  int
+	Text string
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("%d: %s", e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("%d: %s in %q", e.Pos, e.Msg, e.Text)
+}
+
+func errorIn(n Node, format string, args ...interface{}) error {
+	return &Error{Pos: n.Pos(), Text: n.String(), Msg: fmt.Sprintf(format, args...)}
+}
+
+// Shape is the type of an expression: either a scalar or a matrix with a number of rows and columns.
+type Shape struct {
+	Scalar     bool
+	Rows, Cols int
+}
+
+func (s Shape) String() string {
+	if s.Scalar {
+		return "scalar"
+	}
+	return fmt.Sprintf("%dx%d", s.Rows, s.Cols)
+}
+
+var scalar = Shape{Scalar: true}
+
+// Check computes the shape of an expression without evaluating it, failing on
+// the first subexpression whose operands have incompatible dimensions.
+func Check(n Node, env Env) (Shape, error) {
+	switch n := n.(type) {
+	case *Number:
+		return scalar, nil
+	case *Ident:
+		m, ok := env[n.Name]
+		if !ok {
+			return Shape{}, errorIn(n, "undefined matrix %s", n.Name)
+		}
+		if m.IsDegenerate() {
+			return Shape{}, errorIn(n, "matrix %s is not completely filled in", n.Name)
+		}
+		return Shape{Rows: m.RowCount(), Cols: m.ColCount()}, nil
+	case *Unary:
+		return Check(n.X, env)
+	case *Transpose:
+		s, err := Check(n.X, env)
+		if err != nil || s.Scalar {
+			return s, err
+		}
+		return Shape{Rows: s.Cols, Cols: s.Rows}, nil
+	case *Binary:
+		return checkBinary(n, env)
+	case *Call:
+		return checkCall(n, env)
+	case *Concat:
+		return checkConcat(n, env)
+	}
+	return Shape{}, errorIn(n, "unknown expression")
+}
+
+func checkBinary(n *Binary, env Env) (Shape, error) {
+	x, err := Check(n.X, env)
+	if err != nil {
+		return Shape{}, err
+	}
+	y, err := Check(n.Y, env)
+	if err != nil {
+		return Shape{}, err
+	}
+	switch n.Op {
+	case '+', '-':
+		if x.Scalar != y.Scalar {
+			return Shape{}, errorIn(n, "cannot combine %v and %v with %c", x, y, n.Op)
+		}
+		if x != y {
+			return Shape{}, errorIn(n, "dimension mismatch %v %c %v", x, n.Op, y)
+		}
+		return x, nil
+	case '*':
+		switch {
+		case x.Scalar:
+			return y, nil
+		case y.Scalar:
+			return x, nil
+		case x.Cols != y.Rows:
+			return Shape{}, errorIn(n, "dimension mismatch %v * %v", x, y)
+		}
+		return Shape{Rows: x.Rows, Cols: y.Cols}, nil
+	case '/':
+		if !y.Scalar {
+			return Shape{}, errorIn(n, "cannot divide by a %v matrix; use \\ to solve", y)
+		}
+		return x, nil
+	case '\\':
+		if x.Scalar || y.Scalar {
+			return Shape{}, errorIn(n, "\\ needs matrix operands, found %v \\ %v", x, y)
+		}
+		if x.Rows != y.Rows {
+			return Shape{}, errorIn(n, "dimension mismatch %v \\ %v", x, y)
+		}
+		return Shape{Rows: x.Cols, Cols: y.Cols}, nil
+	}
+	return Shape{}, errorIn(n, "unknown operator %c", n.Op)
+}
+
+func checkCall(n *Call, env Env) (Shape, error) {
+	s, err := Check(n.Arg, env)
+	if err != nil {
+		return Shape{}, err
+	}
+	switch n.Func {
+	case "det", "inv":
+		if s.Scalar {
+			return Shape{}, errorIn(n, "%s needs a matrix argument", n.Func)
+		}
+		if s.Rows != s.Cols {
+			return Shape{}, errorIn(n, "%s needs a square matrix, found %v", n.Func, s)
+		}
+		if n.Func == "det" {
+			return scalar, nil
+		}
+		return s, nil
+	case "rref":
+		if s.Scalar {
+			return Shape{}, errorIn(n, "rref needs a matrix argument")
+		}
+		return s, nil
+	}
+	return Shape{}, errorIn(n, "unknown function %s", n.Func)
+}
+
+func checkConcat(n *Concat, env Env) (Shape, error) {
+	var result Shape
+	for i, row := range n.Rows {
+		var rowShape Shape
+		for j, part := range row {
+			s, err := Check(part, env)
+			if err != nil {
+				return Shape{}, err
+			}
+			if s.Scalar {
+				return Shape{}, errorIn(part, "cannot concatenate a scalar")
+			}
+			if j == 0 {
+				rowShape = s
+				continue
+			}
+			if s.Rows != rowShape.Rows {
+				return Shape{}, errorIn(part, "has %d rows but the preceding columns have %d", s.Rows, rowShape.Rows)
+			}
+			rowShape.Cols += s.Cols
+		}
+		if i == 0 {
+			result = rowShape
+			continue
+		}
+		if rowShape.Cols != result.Cols {
+			return Shape{}, errorIn(row[0], "block row has %d columns but the preceding rows have %d", rowShape.Cols, result.Cols)
+		}
+		result.Rows += rowShape.Rows
+	}
+	return result, nil
+}
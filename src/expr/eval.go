@@ -0,0 +1,186 @@
+/*
+	Evaluation of parsed expressions.
+*/
+package expr
+
+import (
+	"fmt"
+	"linear"
+	"strings"
+)
+
+import . "big"
+
+// Value is the result of an expression: a scalar when Scalar is non-nil, otherwise a matrix.
+type Value struct {
+	Scalar *Rat
+	Matrix linear.Matrix
+}
+
+// IsScalar if the value is a single rational rather than a matrix.
+func (v Value) IsScalar() bool {
+	return v.Scalar != nil
+}
+
+func (v Value) String() string {
+	if v.IsScalar() {
+		return v.Scalar.RatString()
+	}
+	rows := make([]string, v.Matrix.RowCount())
+	for i := range rows {
+		cells := make([]string, v.Matrix.ColCount())
+		for j := range cells {
+			cells[j] = v.Matrix.Cell(i, j).RatString()
+		}
+		rows[i] = strings.Join(cells, ", ")
+	}
+	return fmt.Sprintf("[%s]", strings.Join(rows, "; "))
+}
+
+// Evaluate parses, checks and evaluates an expression in one step.
+func Evaluate(src string, env Env) (Value, error) {
+	n, err := Parse(src)
+	if err != nil {
+		return Value{}, err
+	}
+	return Eval(n, env)
+}
+
+// Eval checks the dimensions of an expression and then evaluates it against env.
+func Eval(n Node, env Env) (Value, error) {
+	if _, err := Check(n, env); err != nil {
+		return Value{}, err
+	}
+	return eval(n, env)
+}
+
+// eval assumes n has already been checked.
+func eval(n Node, env Env) (Value, error) {
+	switch n := n.(type) {
+	case *Number:
+		return Value{Scalar: n.Value}, nil
+	case *Ident:
+		return Value{Matrix: env[n.Name]}, nil
+	case *Unary:
+		x, err := eval(n.X, env)
+		if err != nil {
+			return Value{}, err
+		}
+		if x.IsScalar() {
+			return Value{Scalar: new(Rat).Neg(x.Scalar)}, nil
+		}
+		m, _ := x.Matrix.Negate()
+		return Value{Matrix: m}, nil
+	case *Transpose:
+		x, err := eval(n.X, env)
+		if err != nil || x.IsScalar() {
+			return x, err
+		}
+		return Value{Matrix: x.Matrix.Transpose()}, nil
+	case *Binary:
+		return evalBinary(n, env)
+	case *Call:
+		return evalCall(n, env)
+	case *Concat:
+		return evalConcat(n, env)
+	}
+	return Value{}, errorIn(n, "unknown expression")
+}
+
+func evalBinary(n *Binary, env Env) (Value, error) {
+	x, err := eval(n.X, env)
+	if err != nil {
+		return Value{}, err
+	}
+	y, err := eval(n.Y, env)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if x.IsScalar() && y.IsScalar() {
+		switch n.Op {
+		case '+':
+			return Value{Scalar: new(Rat).Add(x.Scalar, y.Scalar)}, nil
+		case '-':
+			return Value{Scalar: new(Rat).Sub(x.Scalar, y.Scalar)}, nil
+		case '*':
+			return Value{Scalar: new(Rat).Mul(x.Scalar, y.Scalar)}, nil
+		}
+	}
+
+	switch n.Op {
+	case '+':
+		m, _ := x.Matrix.Add(y.Matrix)
+		return Value{Matrix: m}, nil
+	case '-':
+		m, _ := x.Matrix.Sub(y.Matrix)
+		return Value{Matrix: m}, nil
+	case '*':
+		if x.IsScalar() {
+			return scale(y, x.Scalar), nil
+		}
+		if y.IsScalar() {
+			return scale(x, y.Scalar), nil
+		}
+		m, _ := x.Matrix.Multiply(y.Matrix)
+		return Value{Matrix: m}, nil
+	case '/':
+		if y.Scalar.Sign() == 0 {
+			return Value{}, errorIn(n, "division by zero")
+		}
+		return scale(x, new(Rat).Quo(NewRat(1, 1), y.Scalar)), nil
+	case '\\':
+		m, ok := x.Matrix.Solve(y.Matrix)
+		if !ok {
+			return Value{}, errorIn(n, "system has no solution")
+		}
+		return Value{Matrix: m}, nil
+	}
+	return Value{}, errorIn(n, "unknown operator %c", n.Op)
+}
+
+func evalCall(n *Call, env Env) (Value, error) {
+	x, err := eval(n.Arg, env)
+	if err != nil {
+		return Value{}, err
+	}
+	switch n.Func {
+	case "det":
+		d, _ := x.Matrix.Det()
+		return Value{Scalar: d}, nil
+	case "inv":
+		m, ok := x.Matrix.Inverse()
+		if !ok {
+			return Value{}, errorIn(n, "matrix is singular")
+		}
+		return Value{Matrix: m}, nil
+	case "rref":
+		m, _ := x.Matrix.AfterGaussJordanElimination()
+		return Value{Matrix: m}, nil
+	}
+	return Value{}, errorIn(n, "unknown function %s", n.Func)
+}
+
+func evalConcat(n *Concat, env Env) (Value, error) {
+	blocks := make([][]linear.Matrix, len(n.Rows))
+	for i, row := range n.Rows {
+		for _, part := range row {
+			x, err := eval(part, env)
+			if err != nil {
+				return Value{}, err
+			}
+			blocks[i] = append(blocks[i], x.Matrix)
+		}
+	}
+	m, _ := linear.Block(blocks)
+	return Value{Matrix: m}, nil
+}
+
+// scale multiplies every entry of v by k.
+func scale(v Value, k *Rat) Value {
+	if v.IsScalar() {
+		return Value{Scalar: new(Rat).Mul(v.Scalar, k)}
+	}
+	m, _ := v.Matrix.Scale(k)
+	return Value{Matrix: m}
+}
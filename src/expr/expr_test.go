@@ -0,0 +1,154 @@
+package expr
+
+import (
+	"linear"
+	"strings"
+	"testing"
+)
+
+import . "big"
+
+func testEnv() Env {
+	a := linear.MakeMatrix(2, 2)
+	a.AddRow(1, 2)
+	a.AddRow(3, 4)
+	b := linear.MakeMatrix(2, 1)
+	b.AddRow(5)
+	b.AddRow(6)
+	c := linear.MakeMatrix(2, 3)
+	c.AddRow(1, 0, 2)
+	c.AddRow(0, 1, 3)
+	return Env{"A": a, "b": b, "C": c}
+}
+
+func evaluate(t *testing.T, src string) Value {
+	v, err := Evaluate(src, testEnv())
+	if err != nil {
+		t.Fatalf("%s: %v", src, err)
+	}
+	return v
+}
+
+func TestScalarArithmeticIsExact(t *testing.T) {
+	v := evaluate(t, "1/3 + 1/6")
+	if !v.IsScalar() || v.Scalar.Cmp(NewRat(1, 2)) != 0 {
+		t.Errorf("Expected 1/2; Actual %v", v)
+	}
+}
+
+func TestDecimalLiteralsAreRational(t *testing.T) {
+	v := evaluate(t, "0.1 * 3")
+	if v.Scalar.Cmp(NewRat(3, 10)) != 0 {
+		t.Errorf("Expected 3/10; Actual %v", v)
+	}
+}
+
+func TestMultiplicationBindsTighterThanAddition(t *testing.T) {
+	v := evaluate(t, "1 + 2 * 3 - -1")
+	if v.Scalar.Cmp(NewRat(8, 1)) != 0 {
+		t.Errorf("Expected 8; Actual %v", v)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	v := evaluate(t, "det(A)")
+	if v.Scalar.Cmp(NewRat(-2, 1)) != 0 {
+		t.Errorf("Expected -2; Actual %v", v)
+	}
+}
+
+func TestProductWithTranspose(t *testing.T) {
+	v := evaluate(t, "C*C'")
+	if v.String() != "[5, 6; 6, 10]" {
+		t.Errorf("Expected [5, 6; 6, 10]; Actual %v", v)
+	}
+}
+
+func TestSolveWithBackslash(t *testing.T) {
+	v := evaluate(t, "A \\ b")
+	if v.String() != "[-4; 9/2]" {
+		t.Errorf("Expected [-4; 9/2]; Actual %v", v)
+	}
+}
+
+func TestReducedRowEchelonFormOfAugmentedMatrix(t *testing.T) {
+	v := evaluate(t, "rref([A|b])")
+	if v.String() != "[1, 0, -4; 0, 1, 9/2]" {
+		t.Errorf("Expected [1, 0, -4; 0, 1, 9/2]; Actual %v", v)
+	}
+}
+
+func TestScalarTimesMatrixMinusMatrix(t *testing.T) {
+	v := evaluate(t, "2*A - A/1")
+	if v.String() != "[1, 2; 3, 4]" {
+		t.Errorf("Expected A; Actual %v", v)
+	}
+}
+
+func TestInverseTimesMatrixIsIdentity(t *testing.T) {
+	v := evaluate(t, "inv(A) * A")
+	if !v.Matrix.Equals(linear.IdentityMatrix(2)) {
+		t.Errorf("Expected identity; Actual %v", v)
+	}
+}
+
+func TestDimensionMismatchReportsSubexpression(t *testing.T) {
+	_, err := Evaluate("det(A) + C*A", testEnv())
+	if err == nil {
+		t.Fatal("C*A should not type check")
+	}
+	e := err.(*Error)
+	if e.Text != "C*A" || e.Pos != 9 {
+		t.Errorf("Expected error in \"C*A\" at 9; Actual %v", err)
+	}
+}
+
+func TestDeterminantOfNonSquareMatrixIsRejected(t *testing.T) {
+	_, err := Evaluate("det(C)", testEnv())
+	if err == nil || !strings.Contains(err.Error(), "square") {
+		t.Errorf("Expected a square matrix error; Actual %v", err)
+	}
+}
+
+func TestUndefinedMatrixIsRejected(t *testing.T) {
+	_, err := Evaluate("A + D", testEnv())
+	if err == nil || err.(*Error).Text != "D" {
+		t.Errorf("Expected an error for D; Actual %v", err)
+	}
+}
+
+func TestConcatenationChecksRowCounts(t *testing.T) {
+	_, err := Evaluate("[A | C']", testEnv())
+	if err == nil || err.(*Error).Text != "C'" {
+		t.Errorf("Expected an error for C'; Actual %v", err)
+	}
+}
+
+func TestBlockMatrix(t *testing.T) {
+	v := evaluate(t, "[A, b; C]")
+	if v.String() != "[1, 2, 5; 3, 4, 6; 1, 0, 2; 0, 1, 3]" {
+		t.Errorf("Expected [1, 2, 5; 3, 4, 6; 1, 0, 2; 0, 1, 3]; Actual %v", v)
+	}
+}
+
+func TestBlockRowsMustHaveMatchingColumns(t *testing.T) {
+	_, err := Evaluate("[A; C]", testEnv())
+	if err == nil || err.(*Error).Text != "C" {
+		t.Errorf("Expected an error for C; Actual %v", err)
+	}
+}
+
+func TestDivisionByZeroIsReportedAtEvaluation(t *testing.T) {
+	_, err := Evaluate("A / (1 - 1)", testEnv())
+	if err == nil || err.(*Error).Text != "A / (1 - 1)" {
+		t.Errorf("Expected a division by zero error; Actual %v", err)
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	for _, src := range []string{"A +", "det(A", "[A | b", "A $ b", "(A))"} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Expected a syntax error for %q", src)
+		}
+	}
+}
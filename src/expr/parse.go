@@ -0,0 +1,266 @@
+/*
+	Lexing and parsing of matrix expressions.
+*/
+package expr
+
+import (
+	"fmt"
+)
+
+import . "big"
+
+const (
+	tokEOF = iota
+	tokNum
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind int
+	pos  int
+	text string
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case isDigit(c):
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{tokNum, start, src[start:i]})
+		case isLetter(c):
+			for i < len(src) && (isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			toks = append(toks, token{tokIdent, start, src[start:i]})
+		case c == '+' || c == '-' || c == '*' || c == '/' || c == '\\' || c == '\'' ||
+			c == '(' || c == ')' || c == '[' || c == ']' || c == '|' || c == ',' || c == ';':
+			i++
+			toks = append(toks, token{tokOp, start, src[start:i]})
+		default:
+			return nil, &Error{Pos: start, Text: src[start : start+1], Msg: "unexpected character"}
+		}
+	}
+	return append(toks, token{tokEOF, len(src), ""}), nil
+}
+
+type parser struct {
+	src  string
+	toks []token
+	next int
+}
+
+// Parse turns an expression into its syntax tree.
+func Parse(src string) (Node, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, toks: toks}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "unexpected "+tok.text)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.toks[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) isOp(ops string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && len(tok.text) == 1 && containsByte(ops, tok.text[0])
+}
+
+func containsByte(s string, c byte) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) (token, error) {
+	tok := p.peek()
+	if tok.kind != tokOp || tok.text != op {
+		if tok.kind == tokEOF {
+			return tok, p.errorAt(tok, "expected "+op+" before end of expression")
+		}
+		return tok, p.errorAt(tok, "expected "+op+" but found "+tok.text)
+	}
+	return p.advance(), nil
+}
+
+func (p *parser) errorAt(tok token, msg string) error {
+	return &Error{Pos: tok.pos, Text: tok.text, Msg: msg}
+}
+
+// spanFrom covers the source from start up to the end of the last consumed token.
+func (p *parser) spanFrom(start int) span {
+	last := p.toks[p.next-1]
+	end := last.pos + len(last.text)
+	return span{start, p.src[start:end]}
+}
+
+// expr := term { ('+' | '-') term }
+func (p *parser) expr() (Node, error) {
+	start := p.peek().pos
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+-") {
+		op := p.advance().text[0]
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{p.spanFrom(start), op, x, y}
+	}
+	return x, nil
+}
+
+// term := unary { ('*' | '/' | '\') unary }
+func (p *parser) term() (Node, error) {
+	start := p.peek().pos
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*/\\") {
+		op := p.advance().text[0]
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{p.spanFrom(start), op, x, y}
+	}
+	return x, nil
+}
+
+// unary := '-' unary | postfix
+func (p *parser) unary() (Node, error) {
+	if p.isOp("-") {
+		start := p.advance().pos
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{p.spanFrom(start), '-', x}, nil
+	}
+	return p.postfix()
+}
+
+// postfix := primary { '\'' }
+func (p *parser) postfix() (Node, error) {
+	start := p.peek().pos
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("'") {
+		p.advance()
+		x = &Transpose{p.spanFrom(start), x}
+	}
+	return x, nil
+}
+
+// primary := number | ident | ident '(' expr ')' | '(' expr ')' | '[' row { ';' row } ']'
+// row := expr { ('|' | ',') expr }
+func (p *parser) primary() (Node, error) {
+	tok := p.advance()
+	switch {
+	case tok.kind == tokNum:
+		v, ok := new(Rat).SetString(tok.text)
+		if !ok {
+			return nil, p.errorAt(tok, "malformed number")
+		}
+		return &Number{span{tok.pos, tok.text}, v}, nil
+	case tok.kind == tokIdent:
+		if !p.isOp("(") {
+			return &Ident{span{tok.pos, tok.text}, tok.text}, nil
+		}
+		p.advance()
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &Call{p.spanFrom(tok.pos), tok.text, arg}, nil
+	case tok.kind == tokOp && tok.text == "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case tok.kind == tokOp && tok.text == "[":
+		var rows [][]Node
+		for {
+			row, err := p.row()
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+			if !p.isOp(";") {
+				break
+			}
+			p.advance()
+		}
+		if _, err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return &Concat{p.spanFrom(tok.pos), rows}, nil
+	case tok.kind == tokEOF:
+		return nil, p.errorAt(tok, "unexpected end of expression")
+	}
+	return nil, p.errorAt(tok, fmt.Sprintf("unexpected %s", tok.text))
+}
+
+func (p *parser) row() ([]Node, error) {
+	var parts []Node
+	for {
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, x)
+		if !p.isOp("|,") {
+			return parts, nil
+		}
+		p.advance()
+	}
+}
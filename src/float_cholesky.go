@@ -0,0 +1,63 @@
+/*
+	Cholesky factorization of floating point matrices.
+*/
+package linear
+
+import (
+	"math"
+)
+
+// Cholesky is a factorization m = L * L' of a symmetric positive definite
+// matrix, where L is lower triangular with a positive diagonal.
+type Cholesky struct {
+	L FloatMatrix
+}
+
+// Cholesky factors a symmetric positive definite matrix, reading only its
+// lower triangle. It fails if a pivot is not positive, which means the
+// matrix is not positive definite or is too badly conditioned to tell.
+func (m FloatMatrix) Cholesky() (Cholesky, bool) {
+	if m.IsEmpty() || m.rows != m.cols {
+		return Cholesky{}, false
+	}
+	n := m.rows
+	l := MakeFloatMatrix(n, n)
+	for j := 0; j < n; j++ {
+		d := m.At(j, j) - dot(l.row(j)[:j], l.row(j)[:j])
+		if d <= 0 {
+			return Cholesky{}, false
+		}
+		ljj := math.Sqrt(d)
+		l.Set(j, j, ljj)
+		for i := j + 1; i < n; i++ {
+			l.Set(i, j, (m.At(i, j)-dot(l.row(i)[:j], l.row(j)[:j]))/ljj)
+		}
+	}
+	return Cholesky{l}, true
+}
+
+// Solve finds x such that L * L' * x = b by forward and back substitution.
+func (f Cholesky) Solve(b FloatMatrix) (FloatMatrix, bool) {
+	n := f.L.rows
+	if b.rows != n || b.IsEmpty() {
+		return EmptyFloatMatrix(), false
+	}
+	x := b.Copy()
+	for c := 0; c < b.cols; c++ {
+		for i := 0; i < n; i++ {
+			s := x.At(i, c)
+			for k := 0; k < i; k++ {
+				s -= f.L.At(i, k) * x.At(k, c)
+			}
+			x.Set(i, c, s/f.L.At(i, i))
+		}
+		for i := n - 1; i >= 0; i-- {
+			s := x.At(i, c)
+			for k := i + 1; k < n; k++ {
+				s -= f.L.At(k, i) * x.At(k, c)
+			}
+			x.Set(i, c, s/f.L.At(i, i))
+		}
+	}
+	return x, true
+}
@@ -0,0 +1,46 @@
+package linear
+
+import (
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	a := floatRows(
+		[]float64{4, 12, -16},
+		[]float64{12, 37, -43},
+		[]float64{-16, -43, 98},
+	)
+	f, success := a.Cholesky()
+	if !success {
+		t.Fatal("Cholesky failed")
+	}
+	expected := floatRows(
+		[]float64{2, 0, 0},
+		[]float64{6, 1, 0},
+		[]float64{-8, 5, 3},
+	)
+	if !f.L.EqualsWithin(expected, 1e-12) {
+		f.L.Print("L =")
+		t.Error("Wrong factor")
+	}
+
+	b := floatRows([]float64{1, 0}, []float64{2, 1}, []float64{3, 0})
+	x, success := f.Solve(b)
+	if !success {
+		t.Fatal("Solve failed")
+	}
+	ax, _ := a.Multiply(x)
+	if !ax.EqualsWithin(b, 1e-9) {
+		t.Error("Wrong solution")
+	}
+}
+
+func TestCholeskyFailsForIndefiniteMatrix(t *testing.T) {
+	a := floatRows(
+		[]float64{1, 2},
+		[]float64{2, 1},
+	)
+	if _, success := a.Cholesky(); success {
+		t.Error("Cholesky should fail")
+	}
+}
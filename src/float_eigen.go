@@ -0,0 +1,211 @@
+/*
+	Eigenvalues of floating point matrices by the QR algorithm.
+*/
+package linear
+
+import (
+	"math"
+	"sort"
+)
+
+// maxQRIterations bounds the iterations spent isolating any one eigenvalue.
+const maxQRIterations = 30
+
+// Hessenberg reduces a square matrix by Householder similarity transforms to
+// upper Hessenberg form, with zeros below the first subdiagonal. The result
+// has the same eigenvalues as the matrix.
+func (m FloatMatrix) Hessenberg() (FloatMatrix, bool) {
+	if m.IsEmpty() || m.rows != m.cols {
+		return EmptyFloatMatrix(), false
+	}
+	h := m.Copy()
+	for j := 0; j+2 < h.rows; j++ {
+		v := h.householder(j+1, j)
+		if v == nil {
+			continue
+		}
+		h.reflectRows(v, j+1, j)
+		h.reflectCols(v, j+1)
+		for i := j + 2; i < h.rows; i++ {
+			h.Set(i, j, 0)
+		}
+	}
+	return h, true
+}
+
+// Eigenvalues of a square matrix, found by reducing it to Hessenberg form
+// and applying the Francis double shift QR algorithm, so that complex
+// conjugate pairs are found without complex arithmetic. They are sorted by
+// real part and then by imaginary part. It fails if the iteration does not converge.
+func (m FloatMatrix) Eigenvalues() ([]complex128, bool) {
+	a, ok := m.Hessenberg()
+	if !ok {
+		return nil, false
+	}
+	values, ok := a.hqr()
+	if !ok {
+		return nil, false
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if real(values[i]) != real(values[j]) {
+			return real(values[i]) < real(values[j])
+		}
+		return imag(values[i]) < imag(values[j])
+	})
+	return values, true
+}
+
+// hqr finds the eigenvalues of an upper Hessenberg matrix, destroying it in
+// the process. Small subdiagonal entries split the matrix into blocks; a 1x1
+// block at the bottom gives a real eigenvalue and a 2x2 block a real or
+// complex pair, and otherwise a double shift QR step is taken.
+func (a FloatMatrix) hqr() ([]complex128, bool) {
+	n := a.rows
+	values := make([]complex128, n)
+	norm := 0.0
+	for _, v := range a.data {
+		norm += math.Abs(v)
+	}
+
+	var p, q, r, s, t, w, x, y, z float64
+	for nn := n - 1; nn >= 0; {
+		its, l := 0, 0
+		for {
+			// Look for a negligible subdiagonal entry.
+			for l = nn; l > 0; l-- {
+				s = math.Abs(a.At(l-1, l-1)) + math.Abs(a.At(l, l))
+				if s == 0 {
+					s = norm
+				}
+				if math.Abs(a.At(l, l-1)) <= epsilon*s {
+					a.Set(l, l-1, 0)
+					break
+				}
+			}
+			x = a.At(nn, nn)
+			if l == nn {
+				values[nn] = complex(x+t, 0)
+				nn--
+				break
+			}
+			y = a.At(nn-1, nn-1)
+			w = a.At(nn, nn-1) * a.At(nn-1, nn)
+			if l == nn-1 {
+				p = 0.5 * (y - x)
+				q = p*p + w
+				z = math.Sqrt(math.Abs(q))
+				x += t
+				if q >= 0 {
+					z = p + math.Copysign(z, p)
+					values[nn-1] = complex(x+z, 0)
+					values[nn] = values[nn-1]
+					if z != 0 {
+						values[nn] = complex(x-w/z, 0)
+					}
+				} else {
+					values[nn] = complex(x+p, -z)
+					values[nn-1] = complex(x+p, z)
+				}
+				nn -= 2
+				break
+			}
+
+			if its == maxQRIterations {
+				return nil, false
+			}
+			if its == 10 || its == 20 {
+				// An exceptional shift breaks cycles the usual shifts fall into.
+				t += x
+				for i := 0; i <= nn; i++ {
+					a.Set(i, i, a.At(i, i)-x)
+				}
+				s = math.Abs(a.At(nn, nn-1)) + math.Abs(a.At(nn-1, nn-2))
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+			its++
+
+			// Find two consecutive small subdiagonal entries to start the step from.
+			m := nn - 2
+			for ; m >= l; m-- {
+				z = a.At(m, m)
+				r = x - z
+				s = y - z
+				p = (r*s-w)/a.At(m+1, m) + a.At(m, m+1)
+				q = a.At(m+1, m+1) - z - r - s
+				r = a.At(m+2, m+1)
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				u := math.Abs(a.At(m, m-1)) * (math.Abs(q) + math.Abs(r))
+				v := math.Abs(p) * (math.Abs(a.At(m-1, m-1)) + math.Abs(z) + math.Abs(a.At(m+1, m+1)))
+				if u <= epsilon*v {
+					break
+				}
+			}
+			for i := m; i < nn-1; i++ {
+				a.Set(i+2, i, 0)
+				if i != m {
+					a.Set(i+2, i-1, 0)
+				}
+			}
+
+			// Chase the bulge down the matrix.
+			for k := m; k < nn; k++ {
+				if k != m {
+					p = a.At(k, k-1)
+					q = a.At(k+1, k-1)
+					r = 0
+					if k+1 != nn {
+						r = a.At(k+2, k-1)
+					}
+					if x = math.Abs(p) + math.Abs(q) + math.Abs(r); x != 0 {
+						p /= x
+						q /= x
+						r /= x
+					}
+				}
+				if s = math.Copysign(math.Sqrt(p*p+q*q+r*r), p); s == 0 {
+					continue
+				}
+				if k == m {
+					if l != m {
+						a.Set(k, k-1, -a.At(k, k-1))
+					}
+				} else {
+					a.Set(k, k-1, -s*x)
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+				for j := k; j <= nn; j++ {
+					p = a.At(k, j) + q*a.At(k+1, j)
+					if k+1 != nn {
+						p += r * a.At(k+2, j)
+						a.Set(k+2, j, a.At(k+2, j)-p*z)
+					}
+					a.Set(k+1, j, a.At(k+1, j)-p*y)
+					a.Set(k, j, a.At(k, j)-p*x)
+				}
+				for i := l; i <= minInt(nn, k+3); i++ {
+					p = x*a.At(i, k) + y*a.At(i, k+1)
+					if k+1 != nn {
+						p += z * a.At(i, k+2)
+						a.Set(i, k+2, a.At(i, k+2)-p*r)
+					}
+					a.Set(i, k+1, a.At(i, k+1)-p*q)
+					a.Set(i, k, a.At(i, k)-p)
+				}
+			}
+		}
+	}
+	return values, true
+}
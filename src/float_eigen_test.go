@@ -0,0 +1,107 @@
+package linear
+
+import (
+	"cmplx"
+	"math"
+	"testing"
+)
+
+func assertEigenvalues(t *testing.T, expected, actual []complex128) {
+	if len(expected) != len(actual) {
+		t.Fatalf("Expected %v; Actual %v", expected, actual)
+	}
+	for i := range expected {
+		if cmplx.Abs(expected[i]-actual[i]) > 1e-9 {
+			t.Errorf("Expected %v; Actual %v", expected, actual)
+			return
+		}
+	}
+}
+
+func TestHessenbergPreservesTrace(t *testing.T) {
+	m := floatSequenceMatrix(5, 5)
+	h, success := m.Hessenberg()
+	if !success {
+		t.Fatal("Hessenberg failed")
+	}
+	trace, hTrace := 0.0, 0.0
+	for i := 0; i < 5; i++ {
+		trace += m.At(i, i)
+		hTrace += h.At(i, i)
+		for j := 0; j+1 < i; j++ {
+			if h.At(i, j) != 0 {
+				t.Errorf("Entry (%d, %d) should be zero", i, j)
+			}
+		}
+	}
+	if math.Abs(trace-hTrace) > 1e-9 {
+		t.Errorf("Expected trace %v; Actual %v", trace, hTrace)
+	}
+}
+
+func TestEigenvaluesOfSymmetricMatrix(t *testing.T) {
+	m := floatRows(
+		[]float64{2, -1, 0},
+		[]float64{-1, 2, -1},
+		[]float64{0, -1, 2},
+	)
+	values, success := m.Eigenvalues()
+	if !success {
+		t.Fatal("Eigenvalues failed")
+	}
+	assertEigenvalues(t, []complex128{complex(2-math.Sqrt2, 0), 2, complex(2+math.Sqrt2, 0)}, values)
+}
+
+func TestEigenvaluesOfRotationAreComplex(t *testing.T) {
+	m := floatRows(
+		[]float64{0, -1},
+		[]float64{1, 0},
+	)
+	values, _ := m.Eigenvalues()
+	assertEigenvalues(t, []complex128{-1i, 1i}, values)
+}
+
+func TestEigenvaluesOfCyclicPermutation(t *testing.T) {
+	m := floatRows(
+		[]float64{0, 0, 0, 1},
+		[]float64{1, 0, 0, 0},
+		[]float64{0, 1, 0, 0},
+		[]float64{0, 0, 1, 0},
+	)
+	values, success := m.Eigenvalues()
+	if !success {
+		t.Fatal("Eigenvalues failed")
+	}
+	assertEigenvalues(t, []complex128{-1, -1i, 1i, 1}, values)
+}
+
+func TestEigenvaluesMatchTraceAndDeterminant(t *testing.T) {
+	m := fractionMatrix(6, 6)
+	f, _ := m.Float()
+	values, success := f.Eigenvalues()
+	if !success {
+		t.Fatal("Eigenvalues failed")
+	}
+	var sum complex128
+	product := complex128(1)
+	for _, v := range values {
+		sum += v
+		product *= v
+	}
+	trace, _ := m.Trace()
+	det, _ := m.Det()
+	expectedTrace, _ := trace.Float64()
+	expectedDet, _ := det.Float64()
+	if cmplx.Abs(sum-complex(expectedTrace, 0)) > 1e-9 {
+		t.Errorf("Expected sum %v; Actual %v", expectedTrace, sum)
+	}
+	if cmplx.Abs(product-complex(expectedDet, 0)) > 1e-9*math.Max(1, math.Abs(expectedDet)) {
+		t.Errorf("Expected product %v; Actual %v", expectedDet, product)
+	}
+}
+
+func TestEigenvaluesFailForNonSquareMatrix(t *testing.T) {
+	if _, success := MakeFloatMatrix(2, 3).Eigenvalues(); success {
+		t.Error("Eigenvalues should fail")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+	Exponential of floating point matrices.
+*/
+package linear
+
+import (
+	"math"
+)
+
+// padeDegree is the degree of the numerator and denominator of the Padé
+// approximant used by Exp, enough for double precision once the matrix has
+// been scaled to have norm at most 1/2.
+const padeDegree = 6
+
+// Exp computes the exponential of a square matrix by scaling and squaring:
+// the matrix is divided by a power of two 2^s to bring its norm below 1/2,
+// the exponential of that is approximated by a diagonal Padé approximant
+// D⁻¹N, and the result is squared s times. It fails if D turns out to be
+// numerically singular.
+func (m FloatMatrix) Exp() (FloatMatrix, bool) {
+	if m.IsEmpty() || m.rows != m.cols {
+		return EmptyFloatMatrix(), false
+	}
+	s := 0
+	if norm := m.normInf(); norm > 0.5 {
+		s = int(math.Ceil(math.Log2(norm / 0.5)))
+	}
+	a := m.scaled(math.Ldexp(1, -s))
+
+	n := IdentityFloatMatrix(m.rows)
+	d := IdentityFloatMatrix(m.rows)
+	power := IdentityFloatMatrix(m.rows)
+	c := 1.0
+	for k := 1; k <= padeDegree; k++ {
+		c *= float64(padeDegree-k+1) / float64(k*(2*padeDegree-k+1))
+		power, _ = power.Multiply(a)
+		n.addScaled(power, c)
+		if k%2 == 0 {
+			d.addScaled(power, c)
+		} else {
+			d.addScaled(power, -c)
+		}
+	}
+	f, _ := d.QR()
+	result, ok := f.Solve(n)
+	if !ok {
+		return EmptyFloatMatrix(), false
+	}
+	for ; s > 0; s-- {
+		result, _ = result.Multiply(result)
+	}
+	return result, true
+}
+
+// normInf is the largest sum of absolute values along a row.
+func (m FloatMatrix) normInf() float64 {
+	norm := 0.0
+	for i := 0; i < m.rows; i++ {
+		sum := 0.0
+		for _, v := range m.row(i) {
+			sum += math.Abs(v)
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+func (m FloatMatrix) scaled(k float64) FloatMatrix {
+	result := m.Copy()
+	for i := range result.data {
+		result.data[i] *= k
+	}
+	return result
+}
+
+// addScaled adds k times m2 to the matrix in place.
+func (m FloatMatrix) addScaled(m2 FloatMatrix, k float64) {
+	for i, v := range m2.data {
+		m.data[i] += k * v
+	}
+}
@@ -0,0 +1,55 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpOfRotationGenerator(t *testing.T) {
+	a := floatRows(
+		[]float64{0, -1},
+		[]float64{1, 0},
+	)
+	e, success := a.Exp()
+	if !success {
+		t.Fatal("Exp failed")
+	}
+	expected := floatRows(
+		[]float64{math.Cos(1), -math.Sin(1)},
+		[]float64{math.Sin(1), math.Cos(1)},
+	)
+	if !e.EqualsWithin(expected, 1e-14) {
+		e.Print("exp =")
+		t.Error("Expected rotation by one radian")
+	}
+}
+
+func TestExpMatchesExactExponential(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(5, 4, 2)
+	m.AddRow(0, 1, -1)
+	m.AddRow(-1, -1, 3)
+	exact, success := m.Exp()
+	if !success {
+		t.Fatal("Exact Exp failed")
+	}
+	f, _ := m.Float()
+	e, success := f.Exp()
+	if !success {
+		t.Fatal("Exp failed")
+	}
+	expected := exact.Float()
+	tol := 1e-12 * expected.normInf()
+	if !e.EqualsWithin(expected, tol) {
+		e.Print("float =")
+		expected.Print("exact =")
+		t.Error("Float and exact exponentials differ")
+	}
+}
+
+func TestExpOfZeroMatrixIsIdentity(t *testing.T) {
+	e, _ := MakeFloatMatrix(3, 3).Exp()
+	if !e.EqualsWithin(IdentityFloatMatrix(3), 0) {
+		t.Error("exp(0) should be I")
+	}
+}
@@ -0,0 +1,145 @@
+/*
+	Floating point matrices.
+*/
+package linear
+
+import (
+	"fmt"
+	"math"
+)
+
+// FloatMatrix is a two-dimensional collection of float64 values, stored row
+// by row. It is the numerical counterpart of Matrix for workloads where exact
+// rational arithmetic is too slow.
+type FloatMatrix struct {
+	data []float64
+	rows int
+	cols int
+}
+
+// EmptyFloatMatrix creates a 0x0 matrix, which is returned by operations that fail.
+func EmptyFloatMatrix() FloatMatrix {
+	return MakeFloatMatrix(0, 0)
+}
+
+// MakeFloatMatrix creates a matrix with the given number of rows and columns filled with zeros.
+func MakeFloatMatrix(rows, cols int) FloatMatrix {
+	return FloatMatrix{data: make([]float64, rows*cols), rows: rows, cols: cols}
+}
+
+// FloatMatrixFromRows creates a matrix from a slice of rows, which must all be the same length.
+func FloatMatrixFromRows(rows ...[]float64) (FloatMatrix, bool) {
+	if len(rows) == 0 {
+		return EmptyFloatMatrix(), true
+	}
+	m := MakeFloatMatrix(len(rows), len(rows[0]))
+	for i, r := range rows {
+		if len(r) != m.cols {
+			return EmptyFloatMatrix(), false
+		}
+		copy(m.row(i), r)
+	}
+	return m, true
+}
+
+// IdentityFloatMatrix creates an NxN matrix with ones on the diagonal and zeros elsewhere.
+func IdentityFloatMatrix(n int) FloatMatrix {
+	m := MakeFloatMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// Float converts a matrix of rationals to the nearest floating point values.
+func (m Matrix) Float() (FloatMatrix, bool) {
+	if m.IsDegenerate() {
+		return EmptyFloatMatrix(), false
+	}
+	f := MakeFloatMatrix(m.rows, m.cols)
+	for i, row := range m.data {
+		for j, v := range row {
+			f.data[i*m.cols+j], _ = cellOrZero(v).Float64()
+		}
+	}
+	return f, true
+}
+
+// RowCount is the number of rows in the matrix.
+func (m FloatMatrix) RowCount() int {
+	return m.rows
+}
+
+// ColCount is the number of columns in the matrix.
+func (m FloatMatrix) ColCount() int {
+	return m.cols
+}
+
+// IsEmpty if number of rows or columns is 0.
+func (m FloatMatrix) IsEmpty() bool {
+	return m.rows == 0 || m.cols == 0
+}
+
+// At returns the value at row, col.
+func (m FloatMatrix) At(row, col int) float64 {
+	return m.data[row*m.cols+col]
+}
+
+// Set the value at row, col.
+func (m FloatMatrix) Set(row, col int, v float64) {
+	m.data[row*m.cols+col] = v
+}
+
+// row is a slice of the matrix's storage; writes to it change the matrix.
+func (m FloatMatrix) row(i int) []float64 {
+	return m.data[i*m.cols : (i+1)*m.cols]
+}
+
+// Copy returns a matrix with the same values which does not share storage with m.
+func (m FloatMatrix) Copy() FloatMatrix {
+	c := MakeFloatMatrix(m.rows, m.cols)
+	copy(c.data, m.data)
+	return c
+}
+
+// Transpose returns a new matrix with the rows and columns of m exchanged.
+func (m FloatMatrix) Transpose() FloatMatrix {
+	t := MakeFloatMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Multiply computes m * m2, dividing the work as MultiplyWith does with the default number of workers.
+func (m FloatMatrix) Multiply(m2 FloatMatrix) (FloatMatrix, bool) {
+	return m.MultiplyWith(m2, 0)
+}
+
+// EqualsWithin is true if the matrices have the same dimensions and no two
+// corresponding values differ by more than tol.
+func (m FloatMatrix) EqualsWithin(m2 FloatMatrix, tol float64) bool {
+	if m.rows != m2.rows || m.cols != m2.cols {
+		return false
+	}
+	for i, v := range m.data {
+		if math.Abs(v-m2.data[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// Print out the matrix values as pretty as possible.
+func (m FloatMatrix) Print(name string) {
+	fmt.Printf("%s\n", name)
+	for i := 0; i < m.rows; i++ {
+		fmt.Printf("\t")
+		for _, v := range m.row(i) {
+			fmt.Printf("%g,", v)
+		}
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+	Householder QR factorization of floating point matrices.
+*/
+package linear
+
+import (
+	"math"
+)
+
+// epsilon is the spacing of float64 values around one.
+const epsilon = 2.220446049250313e-16
+
+// QR is a factorization of a matrix A with column pivoting: the columns of A,
+// reordered by P as P.ApplyCols would, equal Q * R. Q is square and
+// orthogonal, and R is upper triangular with diagonal entries of decreasing size.
+type QR struct {
+	Q, R FloatMatrix
+	P    Permutation
+}
+
+// QR factors the matrix with Householder reflections, at each step choosing
+// the remaining column with the largest norm.
+func (m FloatMatrix) QR() (QR, bool) {
+	if m.IsEmpty() {
+		return QR{}, false
+	}
+	rows, cols := m.rows, m.cols
+	r := m.Copy()
+	q := IdentityFloatMatrix(rows)
+	p := IdentityPermutation(cols)
+
+	for j := 0; j < rows && j < cols; j++ {
+		pivot, best := j, -1.0
+		for c := j; c < cols; c++ {
+			if norm := r.columnNorm2(c, j); norm > best {
+				pivot, best = c, norm
+			}
+		}
+		r.swapCols(j, pivot)
+		p[j], p[pivot] = p[pivot], p[j]
+
+		v := r.householder(j, j)
+		if v == nil {
+			continue
+		}
+		r.reflectRows(v, j, j)
+		q.reflectCols(v, j)
+		for i := j + 1; i < rows; i++ {
+			r.Set(i, j, 0)
+		}
+	}
+	return QR{q, r, p}, true
+}
+
+// columnNorm2 is the squared norm of column c from row `from` down.
+func (m FloatMatrix) columnNorm2(c, from int) float64 {
+	sum := 0.0
+	for i := from; i < m.rows; i++ {
+		sum += m.At(i, c) * m.At(i, c)
+	}
+	return sum
+}
+
+func (m FloatMatrix) swapCols(a, b int) {
+	for i := 0; i < m.rows; i++ {
+		row := m.row(i)
+		row[a], row[b] = row[b], row[a]
+	}
+}
+
+// householder finds the unit vector v for which reflecting through the plane
+// orthogonal to it zeroes column col below row from. It is nil if that part
+// of the column is already zero.
+func (m FloatMatrix) householder(from, col int) []float64 {
+	norm := math.Sqrt(m.columnNorm2(col, from))
+	if norm == 0 {
+		return nil
+	}
+	v := make([]float64, m.rows-from)
+	for i := range v {
+		v[i] = m.At(from+i, col)
+	}
+	// Reflect onto -sign(x0) * |x| to avoid cancellation.
+	if v[0] > 0 {
+		v[0] += norm
+	} else {
+		v[0] -= norm
+	}
+	length := math.Sqrt(dot(v, v))
+	for i := range v {
+		v[i] /= length
+	}
+	return v
+}
+
+// reflectRows applies I - 2vv' to rows from..from+len(v) of the matrix, in columns col onwards.
+func (m FloatMatrix) reflectRows(v []float64, from, col int) {
+	for c := col; c < m.cols; c++ {
+		s := 0.0
+		for i, x := range v {
+			s += x * m.At(from+i, c)
+		}
+		for i, x := range v {
+			m.data[(from+i)*m.cols+c] -= 2 * s * x
+		}
+	}
+}
+
+// reflectCols multiplies columns from..from+len(v) of the matrix by I - 2vv' on the right.
+func (m FloatMatrix) reflectCols(v []float64, from int) {
+	for r := 0; r < m.rows; r++ {
+		row := m.row(r)[from:]
+		s := dot(row[:len(v)], v)
+		for i, x := range v {
+			row[i] -= 2 * s * x
+		}
+	}
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// Rank counts the diagonal entries of R which are larger than tol relative
+// to the first; a tol of zero means the size of the matrix times epsilon.
+func (f QR) Rank(tol float64) int {
+	if tol <= 0 {
+		tol = float64(f.R.rows+f.R.cols) * epsilon
+	}
+	rank := 0
+	for ; rank < f.R.rows && rank < f.R.cols; rank++ {
+		if math.Abs(f.R.At(rank, rank)) <= tol*math.Abs(f.R.At(0, 0)) {
+			break
+		}
+	}
+	return rank
+}
+
+// SolveLeastSquares finds x minimizing the Euclidean norm of a*x - b, for each
+// column of b. When a does not have full column rank, the solution found has
+// zeros for the variables pivoting left out.
+func SolveLeastSquares(a, b FloatMatrix) (FloatMatrix, bool) {
+	if a.rows != b.rows || b.IsEmpty() {
+		return EmptyFloatMatrix(), false
+	}
+	f, ok := a.QR()
+	if !ok {
+		return EmptyFloatMatrix(), false
+	}
+	return f.backSubstitute(b, f.Rank(0)), true
+}
+
+// Solve finds x such that A * x = b for a square A of full rank. It fails
+// when A is numerically singular, where SolveLeastSquares would quietly
+// return a least squares answer instead.
+func (f QR) Solve(b FloatMatrix) (FloatMatrix, bool) {
+	n := f.R.rows
+	if f.R.cols != n || b.rows != n || b.IsEmpty() || f.Rank(0) < n {
+		return EmptyFloatMatrix(), false
+	}
+	return f.backSubstitute(b, n), true
+}
+
+// backSubstitute solves R * P' * x = Q' * b using the leading rank x rank
+// block of R, leaving zero the variables beyond it.
+func (f QR) backSubstitute(b FloatMatrix, rank int) FloatMatrix {
+	c, _ := f.Q.Transpose().Multiply(b)
+	x := MakeFloatMatrix(f.R.cols, b.cols)
+	for k := 0; k < b.cols; k++ {
+		for i := rank - 1; i >= 0; i-- {
+			s := c.At(i, k)
+			for j := i + 1; j < rank; j++ {
+				s -= f.R.At(i, j) * x.At(f.P[j], k)
+			}
+			x.Set(f.P[i], k, s/f.R.At(i, i))
+		}
+	}
+	return x
+}
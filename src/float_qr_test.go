@@ -0,0 +1,129 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+func floatRows(rows ...[]float64) FloatMatrix {
+	m, _ := FloatMatrixFromRows(rows...)
+	return m
+}
+
+func TestQRReconstructsPermutedMatrix(t *testing.T) {
+	a := floatRows(
+		[]float64{1, 2, 3},
+		[]float64{4, 5, 6},
+		[]float64{7, 8, 10},
+		[]float64{-1, 0, 2},
+	)
+	f, success := a.QR()
+	if !success {
+		t.Fatal("QR failed")
+	}
+
+	product, _ := f.Q.Multiply(f.R)
+	permuted := MakeFloatMatrix(a.rows, a.cols)
+	for i := 0; i < a.rows; i++ {
+		for j, p := range f.P {
+			permuted.Set(i, j, a.At(i, p))
+		}
+	}
+	if !product.EqualsWithin(permuted, 1e-12) {
+		t.Error("Q * R should equal A * P")
+	}
+
+	qtq, _ := f.Q.Transpose().Multiply(f.Q)
+	if !qtq.EqualsWithin(IdentityFloatMatrix(4), 1e-12) {
+		t.Error("Q should be orthogonal")
+	}
+	for i := 0; i < f.R.rows; i++ {
+		for j := 0; j < i && j < f.R.cols; j++ {
+			if f.R.At(i, j) != 0 {
+				t.Errorf("R should be upper triangular, found %g at %d,%d", f.R.At(i, j), i, j)
+			}
+		}
+	}
+	for i := 1; i < 3; i++ {
+		if math.Abs(f.R.At(i, i)) > math.Abs(f.R.At(i-1, i-1)) {
+			t.Error("Diagonal of R should not increase")
+		}
+	}
+}
+
+func TestQRRankOfDeficientMatrix(t *testing.T) {
+	a := floatRows(
+		[]float64{1, 2, 3},
+		[]float64{2, 4, 6},
+		[]float64{1, 0, 1},
+	)
+	f, _ := a.QR()
+	Fail(t).If(intsAreNotEqual(2, f.Rank(0)))
+}
+
+func TestQROfEmptyMatrixFails(t *testing.T) {
+	if _, success := EmptyFloatMatrix().QR(); success {
+		t.Fail()
+	}
+}
+
+func TestSolveLeastSquaresFitsLine(t *testing.T) {
+	// Points (0, 1), (1, 3), (2, 4), (3, 4) give the line y = 1.5 + x.
+	a := floatRows([]float64{1, 0}, []float64{1, 1}, []float64{1, 2}, []float64{1, 3})
+	b := floatRows([]float64{1}, []float64{3}, []float64{4}, []float64{4})
+	x, success := SolveLeastSquares(a, b)
+	if !success || !x.EqualsWithin(floatRows([]float64{1.5}, []float64{1}), 1e-12) {
+		x.Print("x = ")
+		t.Fail()
+	}
+}
+
+func TestSolveLeastSquaresIsExactForSquareSystem(t *testing.T) {
+	a := floatRows([]float64{2, 1}, []float64{1, 3})
+	b := floatRows([]float64{3, 1}, []float64{5, 2})
+	x, _ := SolveLeastSquares(a, b)
+	ax, _ := a.Multiply(x)
+	if !ax.EqualsWithin(b, 1e-12) {
+		t.Fail()
+	}
+}
+
+func TestSolveLeastSquaresWithRankDeficientMatrix(t *testing.T) {
+	a := floatRows([]float64{1, 1}, []float64{1, 1}, []float64{1, 1})
+	b := floatRows([]float64{1}, []float64{2}, []float64{3})
+	x, success := SolveLeastSquares(a, b)
+	if !success || math.Abs(x.At(0, 0)+x.At(1, 0)-2) > 1e-12 {
+		t.Fail()
+	}
+}
+
+func TestSolveLeastSquaresWithMismatchedRowsFails(t *testing.T) {
+	if _, success := SolveLeastSquares(MakeFloatMatrix(3, 2), MakeFloatMatrix(2, 1)); success {
+		t.Fail()
+	}
+}
+
+func TestQRSolveSquareSystem(t *testing.T) {
+	a := floatRows([]float64{2, 1, 0}, []float64{1, 3, 1}, []float64{0, 1, 4})
+	b := floatRows([]float64{3}, []float64{5}, []float64{5})
+	f, _ := a.QR()
+	x, success := f.Solve(b)
+	if !success {
+		t.Fatal("Solve failed")
+	}
+	ax, _ := a.Multiply(x)
+	if !ax.EqualsWithin(b, 1e-12) {
+		t.Error("Wrong solution")
+	}
+}
+
+func TestQRSolveFailsForSingularOrNonSquareMatrix(t *testing.T) {
+	singular, _ := floatRows([]float64{1, 2}, []float64{2, 4}).QR()
+	if _, success := singular.Solve(floatRows([]float64{1}, []float64{2})); success {
+		t.Error("Solve should fail for a singular matrix")
+	}
+	tall, _ := floatRows([]float64{1, 0}, []float64{0, 1}, []float64{1, 1}).QR()
+	if _, success := tall.Solve(floatRows([]float64{1}, []float64{2}, []float64{3})); success {
+		t.Error("Solve should fail for a non-square matrix")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+	Singular value decomposition of floating point matrices.
+*/
+package linear
+
+import (
+	"math"
+	"sort"
+)
+
+// maxJacobiSweeps bounds the passes over all pairs of columns in SVD.
+const maxJacobiSweeps = 60
+
+// SVD is a thin singular value decomposition A = U * diag(Sigma) * VT of an
+// r x c matrix, with k = min(r, c). U is r x k and VT is k x c, both with
+// orthonormal rows or columns, and Sigma holds the k singular values in
+// decreasing order.
+type SVD struct {
+	U     FloatMatrix
+	Sigma []float64
+	VT    FloatMatrix
+}
+
+// SVD decomposes the matrix by one-sided Jacobi rotations: pairs of columns
+// are rotated until every pair is orthogonal, at which point their lengths
+// are the singular values. It fails if the rotations do not converge.
+func (m FloatMatrix) SVD() (SVD, bool) {
+	if m.IsEmpty() {
+		return SVD{}, false
+	}
+	if m.rows < m.cols {
+		// A' = U S V' gives A = V S U'.
+		f, ok := m.Transpose().SVD()
+		if !ok {
+			return SVD{}, false
+		}
+		return SVD{f.VT.Transpose(), f.Sigma, f.U.Transpose()}, true
+	}
+
+	w := m.Copy()
+	v := IdentityFloatMatrix(m.cols)
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps && !converged; sweep++ {
+		converged = true
+		for p := 0; p < w.cols; p++ {
+			for q := p + 1; q < w.cols; q++ {
+				alpha, beta, gamma := w.columnDot(p, p), w.columnDot(q, q), w.columnDot(p, q)
+				if gamma == 0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				converged = false
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				w.rotateCols(p, q, c, c*t)
+				v.rotateCols(p, q, c, c*t)
+			}
+		}
+	}
+	if !converged {
+		return SVD{}, false
+	}
+
+	sigma := make([]float64, w.cols)
+	for j := range sigma {
+		sigma[j] = math.Sqrt(w.columnDot(j, j))
+	}
+	order := IdentityPermutation(w.cols)
+	sort.SliceStable(order, func(i, j int) bool { return sigma[order[i]] > sigma[order[j]] })
+
+	u := MakeFloatMatrix(w.rows, w.cols)
+	vt := MakeFloatMatrix(w.cols, w.cols)
+	sorted := make([]float64, w.cols)
+	rank := 0
+	for j, from := range order {
+		sorted[j] = sigma[from]
+		if sigma[from] > sigma[order[0]]*epsilon {
+			rank = j + 1
+			for i := 0; i < w.rows; i++ {
+				u.Set(i, j, w.At(i, from)/sigma[from])
+			}
+		}
+		for i := 0; i < w.cols; i++ {
+			vt.Set(j, i, v.At(i, from))
+		}
+	}
+	u.completeColumns(rank)
+	return SVD{u, sorted, vt}, true
+}
+
+// columnDot is the dot product of columns a and b.
+func (m FloatMatrix) columnDot(a, b int) float64 {
+	sum := 0.0
+	for i := 0; i < m.rows; i++ {
+		sum += m.At(i, a) * m.At(i, b)
+	}
+	return sum
+}
+
+// rotateCols replaces columns p and q with c*p - s*q and s*p + c*q.
+func (m FloatMatrix) rotateCols(p, q int, c, s float64) {
+	for i := 0; i < m.rows; i++ {
+		row := m.row(i)
+		row[p], row[q] = c*row[p]-s*row[q], s*row[p]+c*row[q]
+	}
+}
+
+// completeColumns fills in columns from onwards so that, with the
+// orthonormal columns before them, they are orthonormal. Candidates are
+// the standard basis vectors, orthogonalized twice for accuracy.
+func (m FloatMatrix) completeColumns(from int) {
+	next := 0
+	for j := from; j < m.cols; j++ {
+		for ; next < m.rows; next++ {
+			for i := 0; i < m.rows; i++ {
+				m.Set(i, j, 0)
+			}
+			m.Set(next, j, 1)
+			for pass := 0; pass < 2; pass++ {
+				for k := 0; k < j; k++ {
+					d := m.columnDot(j, k)
+					for i := 0; i < m.rows; i++ {
+						m.Set(i, j, m.At(i, j)-d*m.At(i, k))
+					}
+				}
+			}
+			if norm := math.Sqrt(m.columnDot(j, j)); norm > 0.5 {
+				for i := 0; i < m.rows; i++ {
+					m.Set(i, j, m.At(i, j)/norm)
+				}
+				next++
+				break
+			}
+		}
+	}
+}
+
+// Rank counts the singular values larger than tol relative to the largest;
+// a tol of zero means the size of the matrix times epsilon.
+func (f SVD) Rank(tol float64) int {
+	if tol <= 0 {
+		tol = float64(f.U.rows+f.VT.cols) * epsilon
+	}
+	rank := 0
+	for rank < len(f.Sigma) && f.Sigma[rank] > tol*f.Sigma[0] {
+		rank++
+	}
+	return rank
+}
+
+// Norm2 is the spectral norm of the matrix, its largest singular value.
+func (f SVD) Norm2() float64 {
+	return f.Sigma[0]
+}
+
+// Cond is the condition number of the matrix in the spectral norm: the ratio
+// of its largest to smallest singular values. It is +Inf when the smallest is
+// within max(rows, cols) * epsilon of the largest, as the matrix is then
+// numerically rank deficient and the ratio is rounding noise.
+func (f SVD) Cond() float64 {
+	smallest := f.Sigma[len(f.Sigma)-1]
+	if smallest <= float64(max(f.U.rows, f.VT.cols))*epsilon*f.Sigma[0] {
+		return math.Inf(1)
+	}
+	return f.Sigma[0] / smallest
+}
+
+// Pinv is the Moore-Penrose pseudoinverse V * diag(1/Sigma) * U', with
+// singular values that Rank(tol) treats as zero left out.
+func (f SVD) Pinv(tol float64) FloatMatrix {
+	rank := f.Rank(tol)
+	pinv := MakeFloatMatrix(f.VT.cols, f.U.rows)
+	for i := 0; i < pinv.rows; i++ {
+		for j := 0; j < pinv.cols; j++ {
+			sum := 0.0
+			for k := 0; k < rank; k++ {
+				sum += f.VT.At(k, i) * f.U.At(j, k) / f.Sigma[k]
+			}
+			pinv.Set(i, j, sum)
+		}
+	}
+	return pinv
+}
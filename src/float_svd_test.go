@@ -0,0 +1,124 @@
+package linear
+
+import (
+	"math"
+	"testing"
+)
+
+func assertSVDReconstructs(t *testing.T, a FloatMatrix, f SVD) {
+	k := len(f.Sigma)
+	us := f.U.Copy()
+	for i := 0; i < us.rows; i++ {
+		for j := 0; j < k; j++ {
+			us.Set(i, j, us.At(i, j)*f.Sigma[j])
+		}
+	}
+	product, _ := us.Multiply(f.VT)
+	if !product.EqualsWithin(a, 1e-10) {
+		product.Print("U S V' =")
+		t.Error("Decomposition does not reconstruct the matrix")
+	}
+	utu, _ := f.U.Transpose().Multiply(f.U)
+	if !utu.EqualsWithin(IdentityFloatMatrix(k), 1e-10) {
+		t.Error("U should have orthonormal columns")
+	}
+	vvt, _ := f.VT.Multiply(f.VT.Transpose())
+	if !vvt.EqualsWithin(IdentityFloatMatrix(k), 1e-10) {
+		t.Error("V' should have orthonormal rows")
+	}
+	for j := 1; j < k; j++ {
+		if f.Sigma[j] > f.Sigma[j-1] {
+			t.Errorf("Singular values are not decreasing: %v", f.Sigma)
+		}
+	}
+}
+
+func TestSVDOfTallMatrix(t *testing.T) {
+	a := floatRows(
+		[]float64{3, 2, 2},
+		[]float64{2, 3, -2},
+		[]float64{0, 0, 0},
+		[]float64{1, -1, 4},
+	)
+	f, success := a.SVD()
+	if !success {
+		t.Fatal("SVD failed")
+	}
+	assertSVDReconstructs(t, a, f)
+}
+
+func TestSVDOfWideMatrix(t *testing.T) {
+	a := floatRows(
+		[]float64{3, 2, 2},
+		[]float64{2, 3, -2},
+	)
+	f, success := a.SVD()
+	if !success {
+		t.Fatal("SVD failed")
+	}
+	assertSVDReconstructs(t, a, f)
+	if math.Abs(f.Sigma[0]-5) > 1e-12 || math.Abs(f.Sigma[1]-3) > 1e-12 {
+		t.Errorf("Expected singular values [5 3]; Actual %v", f.Sigma)
+	}
+	if math.Abs(f.Norm2()-5) > 1e-12 || math.Abs(f.Cond()-5.0/3) > 1e-12 {
+		t.Errorf("Expected norm 5 and condition 5/3; Actual %v and %v", f.Norm2(), f.Cond())
+	}
+}
+
+func TestSVDOfRankDeficientMatrix(t *testing.T) {
+	// Entry (i, j) is i + j, so every row is a combination of two vectors.
+	a := MakeFloatMatrix(5, 4)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 4; j++ {
+			a.Set(i, j, float64(i+j))
+		}
+	}
+	f, success := a.SVD()
+	if !success {
+		t.Fatal("SVD failed")
+	}
+	assertSVDReconstructs(t, a, f)
+	Fail(t).If(intsAreNotEqual(2, f.Rank(0)))
+	if !math.IsInf(f.Cond(), 1) {
+		t.Errorf("Expected an infinite condition number; Actual %v", f.Cond())
+	}
+
+	// The pseudoinverse satisfies A A+ A = A and A+ A A+ = A+.
+	pinv := f.Pinv(0)
+	aPinv, _ := a.Multiply(pinv)
+	aPinvA, _ := aPinv.Multiply(a)
+	if !aPinvA.EqualsWithin(a, 1e-9) {
+		t.Error("A A+ A should equal A")
+	}
+	pinvA, _ := pinv.Multiply(a)
+	pinvAPinv, _ := pinvA.Multiply(pinv)
+	if !pinvAPinv.EqualsWithin(pinv, 1e-9) {
+		t.Error("A+ A A+ should equal A+")
+	}
+}
+
+func TestPinvOfInvertibleMatrixIsInverse(t *testing.T) {
+	a := floatRows(
+		[]float64{4, 7},
+		[]float64{2, 6},
+	)
+	f, _ := a.SVD()
+	expected := floatRows(
+		[]float64{0.6, -0.7},
+		[]float64{-0.2, 0.4},
+	)
+	if !f.Pinv(0).EqualsWithin(expected, 1e-12) {
+		f.Pinv(0).Print("pinv =")
+		t.Error("Wrong pseudoinverse")
+	}
+}
+
+func TestSVDOfZeroMatrix(t *testing.T) {
+	a := MakeFloatMatrix(3, 2)
+	f, success := a.SVD()
+	if !success {
+		t.Fatal("SVD failed")
+	}
+	assertSVDReconstructs(t, a, f)
+	Fail(t).If(intsAreNotEqual(0, f.Rank(0)))
+}
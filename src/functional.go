@@ -0,0 +1,163 @@
+/*
+	Bulk elementwise operations and reductions.
+*/
+package linear
+
+import . "big"
+
+// Map returns a new matrix whose cells are fn applied to each cell of m.
+// fn is given a copy of each cell, with unset cells as zero, so the new
+// matrix shares nothing with m.
+func (m Matrix) Map(fn func(i, j int, v *Rat) *Rat) (Matrix, bool) {
+	if m.IsDegenerate() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(m.rows, m.cols)
+	for i, row := range m.data {
+		result.data[i] = make(MatrixRow, m.cols)
+		for j, v := range row {
+			result.data[i][j] = fn(i, j, copyCell(v))
+		}
+	}
+	return result, true
+}
+
+// Apply replaces each cell of m with fn applied to it. fn is given a copy of
+// each cell, with unset cells as zero.
+func (m Matrix) Apply(fn func(i, j int, v *Rat) *Rat) bool {
+	if m.IsDegenerate() {
+		return false
+	}
+	for i, row := range m.data {
+		for j, v := range row {
+			row[j] = fn(i, j, copyCell(v))
+		}
+	}
+	return true
+}
+
+// zipWith combines the corresponding cells of two matrices of the same dimensions.
+func (m Matrix) zipWith(m2 Matrix, fn func(a, b *Rat) *Rat) (Matrix, bool) {
+	if m.IsDegenerate() || m2.IsDegenerate() {
+		return EmptyMatrix(), false
+	}
+	if !m.hasSameDimension(m2) {
+		return EmptyMatrix(), false
+	}
+	return m.Map(func(i, j int, v *Rat) *Rat {
+		return fn(v, copyCell(m2.data[i][j]))
+	})
+}
+
+// Sub subtracts the given matrix from another matrix.
+func (m Matrix) Sub(subtrahend Matrix) (Matrix, bool) {
+	return m.zipWith(subtrahend, func(a, b *Rat) *Rat {
+		return new(Rat).Sub(a, b)
+	})
+}
+
+// Hadamard multiplies the matrices cell by cell.
+func (m Matrix) Hadamard(m2 Matrix) (Matrix, bool) {
+	return m.zipWith(m2, func(a, b *Rat) *Rat {
+		return new(Rat).Mul(a, b)
+	})
+}
+
+// Scale multiplies every cell of the matrix by k.
+func (m Matrix) Scale(k *Rat) (Matrix, bool) {
+	return m.Map(func(i, j int, v *Rat) *Rat {
+		return new(Rat).Mul(v, k)
+	})
+}
+
+// Negate changes the sign of every cell of the matrix.
+func (m Matrix) Negate() (Matrix, bool) {
+	return m.Map(func(i, j int, v *Rat) *Rat {
+		return new(Rat).Neg(v)
+	})
+}
+
+// reduce folds fn over a row, starting from its first value. Unset cells count as zero.
+func (mr MatrixRow) reduce(fn func(acc, v *Rat) *Rat) *Rat {
+	acc := new(Rat).Set(cellOrZero(mr[0]))
+	for _, v := range mr[1:] {
+		acc = fn(acc, copyCell(v))
+	}
+	return acc
+}
+
+// ReduceRows folds fn over each row, giving a matrix with a single column.
+func (m Matrix) ReduceRows(fn func(acc, v *Rat) *Rat) (Matrix, bool) {
+	if m.IsDegenerate() || m.IsEmpty() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(m.rows, 1)
+	for i, row := range m.data {
+		result.data[i] = MatrixRow{row.reduce(fn)}
+	}
+	return result, true
+}
+
+// ReduceCols folds fn over each column, giving a matrix with a single row.
+func (m Matrix) ReduceCols(fn func(acc, v *Rat) *Rat) (Matrix, bool) {
+	if m.IsDegenerate() || m.IsEmpty() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(1, m.cols)
+	result.data[0] = make(MatrixRow, m.cols)
+	for j := 0; j < m.cols; j++ {
+		result.data[0][j] = m.getCol(j).reduce(fn)
+	}
+	return result, true
+}
+
+// reduceAll folds fn over every cell, row by row.
+func (m Matrix) reduceAll(fn func(acc, v *Rat) *Rat) (*Rat, bool) {
+	columns, ok := m.ReduceRows(fn)
+	if !ok {
+		return nil, false
+	}
+	return columns.getCol(0).reduce(fn), true
+}
+
+// Sum adds up every cell of the matrix.
+func (m Matrix) Sum() (*Rat, bool) {
+	return m.reduceAll(func(acc, v *Rat) *Rat {
+		return acc.Add(acc, v)
+	})
+}
+
+// Max is the largest value in the matrix.
+func (m Matrix) Max() (*Rat, bool) {
+	return m.reduceAll(func(acc, v *Rat) *Rat {
+		if v.Cmp(acc) > 0 {
+			return acc.Set(v)
+		}
+		return acc
+	})
+}
+
+// Min is the smallest value in the matrix.
+func (m Matrix) Min() (*Rat, bool) {
+	return m.reduceAll(func(acc, v *Rat) *Rat {
+		if v.Cmp(acc) < 0 {
+			return acc.Set(v)
+		}
+		return acc
+	})
+}
+
+// Argmax is the position of the largest value in the matrix, with unset cells
+// counting as zero. Ties go to the first such cell, row by row.
+func (m Matrix) Argmax() (Index, bool) {
+	if m.IsDegenerate() || m.IsEmpty() {
+		return Index{}, false
+	}
+	best := Index{0, 0}
+	for idx, v := range m.Cells() {
+		if compareCells(v, m.data[best.Row][best.Col]) > 0 {
+			best = idx
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,174 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestMapLeavesMatrixUntouched(t *testing.T) {
+	m := unitMatrix(3)
+	doubled, success := m.Map(func(i, j int, v *Rat) *Rat {
+		return new(Rat).Add(v, v)
+	})
+	if !success {
+		t.Fail()
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(2, 1), doubled.data[1][1]))
+	if !m.Equals(unitMatrix(3)) {
+		t.Fail()
+	}
+}
+
+func TestMapSharesNoCellsWithMatrix(t *testing.T) {
+	m := unitMatrix(2)
+	same, _ := m.Map(func(i, j int, v *Rat) *Rat {
+		return v.Add(v, v)
+	})
+	if !m.Equals(unitMatrix(2)) {
+		t.Error("fn changed the matrix through its argument")
+	}
+	for _, v := range same.Cells() {
+		v.SetInt64(7)
+	}
+	if !m.Equals(unitMatrix(2)) {
+		t.Error("Changing the result changed the matrix")
+	}
+}
+
+func TestMapPassesCellPosition(t *testing.T) {
+	m, _ := ZeroMatrix(2, 3).Map(func(i, j int, v *Rat) *Rat {
+		return NewRat(int64(10*i+j), 1)
+	})
+	Fail(t).If(rationalsAreNotEqual(NewRat(12, 1), m.data[1][2]))
+}
+
+func TestApplyChangesMatrixInPlace(t *testing.T) {
+	m := unitMatrix(2)
+	m.Apply(func(i, j int, v *Rat) *Rat {
+		return NewRat(int64(i+j), 1)
+	})
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), m.data[0][1]))
+}
+
+func TestMapOnDegenerateMatrixFails(t *testing.T) {
+	_, success := MakeMatrix(2, 2).Map(func(i, j int, v *Rat) *Rat { return v })
+	if success {
+		t.Fail()
+	}
+}
+
+func TestSubtractingMatrixFromItselfIsZeroMatrix(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	diff, success := m.Sub(m)
+	if !success || !diff.Equals(ZeroMatrix(4, 4)) {
+		t.Fail()
+	}
+}
+
+func TestSubFailsWithDifferentDimensions(t *testing.T) {
+	_, success := ZeroMatrix(2, 3).Sub(ZeroMatrix(3, 2))
+	if success {
+		t.Fail()
+	}
+}
+
+func TestHadamardOfUnitMatrixKeepsDiagonal(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	actual, _ := m.Hadamard(unitMatrix(4))
+	expected := MakeMatrix(4, 4)
+	expected.AddRow(1, 0, 0, 0)
+	expected.AddRow(0, 2, 0, 0)
+	expected.AddRow(0, 0, 3, 0)
+	expected.AddRow(0, 0, 0, 4)
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestScaleAndNegate(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	scaled, _ := m.Scale(NewRat(-1, 1))
+	negated, _ := m.Negate()
+	if !scaled.Equals(negated) {
+		t.Fail()
+	}
+	sum, _ := m.Add(negated)
+	if !sum.Equals(ZeroMatrix(4, 4)) {
+		t.Fail()
+	}
+}
+
+func TestReduceRowsAndCols(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, 2, 3)
+	m.AddRow(4, 5, 6)
+	add := func(acc, v *Rat) *Rat { return acc.Add(acc, v) }
+
+	rows, _ := m.ReduceRows(add)
+	expectedRows := MakeMatrix(2, 1)
+	expectedRows.AddRow(6)
+	expectedRows.AddRow(15)
+	if !rows.Equals(expectedRows) {
+		t.Error("ReduceRows")
+	}
+
+	cols, _ := m.ReduceCols(add)
+	expectedCols := MakeMatrix(1, 3)
+	expectedCols.AddRow(5, 7, 9)
+	if !cols.Equals(expectedCols) {
+		t.Error("ReduceCols")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), m.data[0][0]))
+}
+
+func TestSumMaxMinArgmax(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, -7, 3)
+	m.AddRow(9, 5, 9)
+
+	sum, _ := m.Sum()
+	Fail(t).If(rationalsAreNotEqual(NewRat(20, 1), sum))
+	max, _ := m.Max()
+	Fail(t).If(rationalsAreNotEqual(NewRat(9, 1), max))
+	min, _ := m.Min()
+	Fail(t).If(rationalsAreNotEqual(NewRat(-7, 1), min))
+	idx, _ := m.Argmax()
+	if idx != (Index{1, 0}) {
+		t.Errorf("Expected {1 0}; Actual %v", idx)
+	}
+}
+
+func TestReductionsOfEmptyMatrixFail(t *testing.T) {
+	if _, success := EmptyMatrix().Sum(); success {
+		t.Fail()
+	}
+	if _, success := EmptyMatrix().Argmax(); success {
+		t.Fail()
+	}
+}
+
+func TestUnsetCellsCountAsZero(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(-1, -2)
+	m.AddRow(-3)
+
+	scaled, success := m.Scale(NewRat(2, 1))
+	expected := MakeMatrix(2, 3)
+	expected.AddRow(-2, -4, 0)
+	expected.AddRow(-6, 0, 0)
+	if !success || !scaled.Equals(expected) {
+		t.Errorf("Expected %v; Actual %v", expected, scaled)
+	}
+	max, _ := m.Max()
+	Fail(t).If(rationalsAreNotEqual(new(Rat), max))
+	sum, _ := m.Sum()
+	Fail(t).If(rationalsAreNotEqual(NewRat(-6, 1), sum))
+	idx, _ := m.Argmax()
+	if idx != (Index{0, 2}) {
+		t.Errorf("Expected {0 2}; Actual %v", idx)
+	}
+	if zero.Sign() != 0 {
+		t.Error("The shared zero was changed")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+	Exact Gram-Schmidt orthogonalization.
+*/
+package linear
+
+import . "big"
+
+// GramSchmidtWithCoefficients orthogonalizes the vectors in order, without
+// normalizing them since norms are generally irrational. It returns one
+// orthogonal vector u[j] for each input v[j], and the unit upper triangular
+// matrix r for which v[j] is the sum of r[i][j] * u[i]. When v[j] is a
+// combination of the vectors before it, u[j] is zero. It fails if the vectors
+// are not all the same length.
+func GramSchmidtWithCoefficients(vs []Vector) (us []Vector, r Matrix, ok bool) {
+	for _, v := range vs {
+		if len(v) != len(vs[0]) {
+			return nil, EmptyMatrix(), false
+		}
+	}
+
+	us = make([]Vector, len(vs))
+	norms := make([]*Rat, len(vs))
+	r = IdentityMatrix(len(vs))
+	for j, v := range vs {
+		u := v.Scale(NewRat(1, 1))
+		for i := 0; i < j; i++ {
+			if norms[i].Sign() == 0 {
+				continue
+			}
+			// Projecting the partly orthogonalized u rather than v gives the
+			// same coefficient in exact arithmetic, since the difference is
+			// already orthogonal to us[i].
+			dot, _ := u.Dot(us[i])
+			coeff := dot.Quo(dot, norms[i])
+			r.data[i][j] = coeff
+			u, _ = u.Sub(us[i].Scale(coeff))
+		}
+		us[j] = u
+		norms[j] = u.Norm2Squared()
+	}
+	return us, r, true
+}
+
+// GramSchmidt finds an orthogonal basis for the span of the vectors, keeping
+// them in order and dropping each one which is a combination of those before
+// it. independent is true if none had to be dropped.
+func GramSchmidt(vs []Vector) (basis []Vector, independent bool) {
+	us, _, ok := GramSchmidtWithCoefficients(vs)
+	if !ok {
+		return nil, false
+	}
+	independent = true
+	for _, u := range us {
+		if u.IsZero() {
+			independent = false
+			continue
+		}
+		basis = append(basis, u)
+	}
+	return basis, independent
+}
+
+// OrthogonalBasis finds an orthogonal basis for the column space of the matrix.
+func (m Matrix) OrthogonalBasis() ([]Vector, bool) {
+	if m.IsDegenerate() {
+		return nil, false
+	}
+	cols := make([]Vector, m.cols)
+	for j := range cols {
+		cols[j], _ = m.Col(j)
+	}
+	basis, _ := GramSchmidt(cols)
+	return basis, true
+}
@@ -0,0 +1,97 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func assertOrthogonal(t *testing.T, vs []Vector) {
+	for i := range vs {
+		for j := i + 1; j < len(vs); j++ {
+			if dot, _ := vs[i].Dot(vs[j]); dot.Sign() != 0 {
+				t.Errorf("Vectors %d and %d are not orthogonal", i, j)
+			}
+		}
+	}
+}
+
+func TestGramSchmidtOfIndependentVectors(t *testing.T) {
+	vs := []Vector{NewVector(1, 1, 0), NewVector(1, 0, 1), NewVector(0, 1, 1)}
+	basis, independent := GramSchmidt(vs)
+	if !independent || len(basis) != 3 {
+		t.Fatal("Vectors should be independent")
+	}
+	assertOrthogonal(t, basis)
+	half := NewRat(1, 2)
+	if !basis[1].Equals(Vector{half, new(Rat).Neg(half), NewRat(1, 1)}) {
+		t.Errorf("Expected [1/2 -1/2 1]; Actual %v", basis[1])
+	}
+}
+
+func TestGramSchmidtDetectsDependence(t *testing.T) {
+	vs := []Vector{NewVector(1, 2, 3), NewVector(2, 4, 6), NewVector(0, 0, 1)}
+	basis, independent := GramSchmidt(vs)
+	if independent {
+		t.Error("Second vector is a multiple of the first")
+	}
+	Fail(t).If(intsAreNotEqual(2, len(basis)))
+	assertOrthogonal(t, basis)
+}
+
+func TestGramSchmidtCoefficientsReconstructInputs(t *testing.T) {
+	vs := []Vector{NewVector(3, 1, 2), NewVector(1, 1, 1), NewVector(4, 2, 3), NewVector(0, 5, -1)}
+	us, r, ok := GramSchmidtWithCoefficients(vs)
+	if !ok {
+		t.Fatal("GramSchmidtWithCoefficients failed")
+	}
+	if !us[2].IsZero() {
+		t.Error("Third vector is the sum of the first two")
+	}
+
+	u := MakeMatrix(3, len(us))
+	for j, v := range us {
+		for i, x := range v {
+			u.SetCell(i, j, x)
+		}
+	}
+	v := MakeMatrix(3, len(vs))
+	for j, x := range vs {
+		for i, y := range x {
+			v.SetCell(i, j, y)
+		}
+	}
+	product, _ := u.Multiply(r)
+	if !product.Equals(v) {
+		t.Error("U * R should equal V")
+	}
+	for i := range r.data {
+		for j := 0; j <= i; j++ {
+			expected := NewRat(0, 1)
+			if i == j {
+				expected = NewRat(1, 1)
+			}
+			Fail(t).If(rationalsAreNotEqual(expected, r.data[i][j]))
+		}
+	}
+}
+
+func TestGramSchmidtWithDifferentLengthsFails(t *testing.T) {
+	if _, _, ok := GramSchmidtWithCoefficients([]Vector{NewVector(1), NewVector(1, 2)}); ok {
+		t.Fail()
+	}
+}
+
+func TestOrthogonalBasisOfColumnSpace(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(1, 2, 0)
+	m.AddRow(0, 0, 1)
+	m.AddRow(1, 2, 1)
+	basis, success := m.OrthogonalBasis()
+	if !success {
+		t.Fail()
+	}
+	rank, _ := m.Rank()
+	Fail(t).If(intsAreNotEqual(rank, len(basis)))
+	assertOrthogonal(t, basis)
+}
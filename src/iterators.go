@@ -0,0 +1,96 @@
+/*
+	Iteration over the rows, columns and cells of a matrix.
+*/
+package linear
+
+import (
+	"iter"
+)
+
+import . "big"
+
+// Index is the position of a cell in a matrix.
+type Index struct {
+	Row, Col int
+}
+
+// Rows yields each row of the matrix with its index. The rows share storage
+// with the matrix, so changing one changes the matrix.
+func (m Matrix) Rows() iter.Seq2[int, MatrixRow] {
+	return func(yield func(int, MatrixRow) bool) {
+		for i, row := range m.data {
+			if !yield(i, row) {
+				return
+			}
+		}
+	}
+}
+
+// Cols yields each column of the matrix with its index. Each column is a new
+// slice, but its cells are the live values held by the matrix, as with Cells,
+// and cells which have not been set are nil.
+func (m Matrix) Cols() iter.Seq2[int, MatrixRow] {
+	return func(yield func(int, MatrixRow) bool) {
+		for j := 0; j < m.cols; j++ {
+			col := make(MatrixRow, m.rows)
+			for i, row := range m.data {
+				if len(row) > j {
+					col[i] = row[j]
+				}
+			}
+			if !yield(j, col) {
+				return
+			}
+		}
+	}
+}
+
+// Cells yields every cell of the matrix, row by row. The cells are the live
+// values held by the matrix, not copies, and cells which have not been set
+// are yielded as nil.
+func (m Matrix) Cells() iter.Seq2[Index, *Rat] {
+	return func(yield func(Index, *Rat) bool) {
+		for i := 0; i < m.rows; i++ {
+			for j := 0; j < m.cols; j++ {
+				var v *Rat
+				if len(m.data[i]) > j {
+					v = m.data[i][j]
+				}
+				if !yield(Index{i, j}, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NonZeros yields the cells of the matrix which are set and not zero, row by row.
+func (m Matrix) NonZeros() iter.Seq2[Index, *Rat] {
+	return func(yield func(Index, *Rat) bool) {
+		for i, row := range m.data {
+			for j, v := range row {
+				if v == nil || v.Sign() == 0 {
+					continue
+				}
+				if !yield(Index{i, j}, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Diagonal yields the cells (i, i) of the matrix, as far as the shorter dimension allows.
+func (m Matrix) Diagonal() iter.Seq2[int, *Rat] {
+	return func(yield func(int, *Rat) bool) {
+		for i := 0; i < m.rows && i < m.cols; i++ {
+			var v *Rat
+			if len(m.data[i]) > i {
+				v = m.data[i][i]
+			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestRowsYieldsEveryRowInOrder(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	count := 0
+	for i, row := range m.Rows() {
+		Fail(t).If(intsAreNotEqual(count, i))
+		Fail(t).If(rationalsAreNotEqual(NewRat(int64(i+1), 1), row[0]))
+		count++
+	}
+	Fail(t).If(intsAreNotEqual(4, count))
+}
+
+func TestColsYieldsColumns(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, 2, 3)
+	m.AddRow(4, 5, 6)
+	for j, col := range m.Cols() {
+		Fail(t).If(intsAreNotEqual(2, len(col)))
+		Fail(t).If(rationalsAreNotEqual(NewRat(int64(j+4), 1), col[1]))
+	}
+}
+
+func TestCellsCanStopEarly(t *testing.T) {
+	count := 0
+	for idx, v := range nonZeroMatrix4x4().Cells() {
+		count++
+		if idx.Row == 1 && idx.Col == 1 {
+			Fail(t).If(rationalsAreNotEqual(NewRat(2, 1), v))
+			break
+		}
+	}
+	Fail(t).If(intsAreNotEqual(6, count))
+}
+
+func TestCellsYieldsNilForUnsetCells(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.SetCell(0, 1, 3)
+	nils := 0
+	for _, v := range m.Cells() {
+		if v == nil {
+			nils++
+		}
+	}
+	Fail(t).If(intsAreNotEqual(3, nils))
+}
+
+func TestNonZerosSkipsZeros(t *testing.T) {
+	m := unitMatrix(5)
+	count := 0
+	for idx, v := range m.NonZeros() {
+		if idx.Row != idx.Col || v.Cmp(NewRat(1, 1)) != 0 {
+			t.Errorf("Unexpected value %v at %v", v, idx)
+		}
+		count++
+	}
+	Fail(t).If(intsAreNotEqual(5, count))
+}
+
+func TestDiagonalOfNonSquareMatrix(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, 2, 3)
+	m.AddRow(4, 5, 6)
+	sum := NewRat(0, 1)
+	count := 0
+	for _, v := range m.Diagonal() {
+		sum.Add(sum, v)
+		count++
+	}
+	Fail(t).If(intsAreNotEqual(2, count))
+	Fail(t).If(rationalsAreNotEqual(NewRat(6, 1), sum))
+}
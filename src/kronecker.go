@@ -0,0 +1,106 @@
+/*
+	Kronecker products, direct sums and vectorization.
+*/
+package linear
+
+import . "big"
+
+// sparseDensity is the fraction of nonzero cells below which a matrix is
+// treated as sparse, and only its nonzero cells are visited.
+const sparseDensity = 0.25
+
+// nonZeroCount is the number of cells which are set and not zero.
+func (m Matrix) nonZeroCount() (count int) {
+	for range m.NonZeros() {
+		count++
+	}
+	return
+}
+
+// isSparse if few enough of the matrix's cells are nonzero that skipping the zeros pays off.
+func (m Matrix) isSparse() bool {
+	return float64(m.nonZeroCount()) < sparseDensity*float64(m.rows*m.cols)
+}
+
+// Kron computes the Kronecker product, in which each cell a of m is replaced
+// by the block a * m2. When either matrix is sparse, only products of nonzero
+// cells are computed.
+func Kron(m, m2 Matrix) (Matrix, bool) {
+	if m.IsDegenerate() || m2.IsDegenerate() {
+		return EmptyMatrix(), false
+	}
+	result := ZeroMatrix(m.rows*m2.rows, m.cols*m2.cols)
+	if m.isSparse() || m2.isSparse() {
+		for a, x := range m.NonZeros() {
+			for b, y := range m2.NonZeros() {
+				result.data[a.Row*m2.rows+b.Row][a.Col*m2.cols+b.Col] = new(Rat).Mul(x, y)
+			}
+		}
+		return result, true
+	}
+
+	for i, row := range m.data {
+		for j, x := range row {
+			for k, row2 := range m2.data {
+				out := result.data[i*m2.rows+k][j*m2.cols:]
+				for l, y := range row2 {
+					out[l] = new(Rat).Mul(cellOrZero(x), cellOrZero(y))
+				}
+			}
+		}
+	}
+	return result, true
+}
+
+// DirectSum places the matrices along the diagonal of a larger matrix, with zeros elsewhere.
+func DirectSum(ms ...Matrix) (Matrix, bool) {
+	return BlockDiag(ms...)
+}
+
+// Vec stacks the columns of the matrix into a single column, first column on top.
+func (m Matrix) Vec() (Matrix, bool) {
+	if m.IsDegenerate() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(m.rows*m.cols, 1)
+	for j := 0; j < m.cols; j++ {
+		for i, row := range m.data {
+			result.data[j*m.rows+i] = MatrixRow{new(Rat).Set(cellOrZero(row[j]))}
+		}
+	}
+	return result, true
+}
+
+// Unvec undoes Vec, turning a single column back into a matrix with the given number of rows.
+func (m Matrix) Unvec(rows int) (Matrix, bool) {
+	if m.IsDegenerate() || m.cols != 1 || rows <= 0 || m.rows%rows != 0 {
+		return EmptyMatrix(), false
+	}
+	cols := m.rows / rows
+	result := MakeMatrix(rows, cols)
+	for i := range result.data {
+		result.data[i] = make(MatrixRow, cols)
+		for j := range result.data[i] {
+			result.data[i][j] = new(Rat).Set(cellOrZero(m.data[j*rows+i][0]))
+		}
+	}
+	return result, true
+}
+
+// CommutationMatrix is the permutation matrix K for which K * vec(A) = vec(A')
+// for every matrix A with the given number of rows and columns.
+func CommutationMatrix(rows, cols int) Matrix {
+	return commutation(rows, cols).Matrix()
+}
+
+// commutation is the permutation behind CommutationMatrix: cell (i, j) of A
+// sits at j*rows+i in vec(A), and at i*cols+j in vec(A').
+func commutation(rows, cols int) Permutation {
+	p := make(Permutation, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			p[i*cols+j] = j*rows + i
+		}
+	}
+	return p
+}
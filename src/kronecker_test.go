@@ -0,0 +1,130 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestKronWithUnitMatrixRepeatsBlocks(t *testing.T) {
+	b := sequenceMatrix(2, 2)
+	actual, success := Kron(unitMatrix(2), b)
+	expected, _ := BlockDiag(b, b)
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestKronOfDenseMatrices(t *testing.T) {
+	a := MakeMatrix(1, 2)
+	a.AddRow(1, 2)
+	b := MakeMatrix(2, 1)
+	b.AddRow(3)
+	b.AddRow(4)
+	expected := MakeMatrix(2, 2)
+	expected.AddRow(3, 6)
+	expected.AddRow(4, 8)
+
+	actual, _ := Kron(a, b)
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestKronAndVecTreatUnsetCellsAsZero(t *testing.T) {
+	a := MakeMatrix(2, 2)
+	a.AddRow(1, 2)
+	a.AddRow(3)
+	b := nonZeroMatrix(2, 2)
+	full := MakeMatrix(2, 2)
+	full.AddRow(1, 2)
+	full.AddRow(3, 0)
+
+	actual, success := Kron(a, b)
+	expected, _ := Kron(full, b)
+	if !success || !actual.Equals(expected) {
+		t.Errorf("Expected %v; Actual %v", expected, actual)
+	}
+	vec, success := a.Vec()
+	expected, _ = full.Vec()
+	if !success || !vec.Equals(expected) {
+		t.Errorf("Expected %v; Actual %v", expected, vec)
+	}
+}
+
+func TestKronSparseAndDensePathsAgree(t *testing.T) {
+	sparse := ZeroMatrix(3, 4)
+	sparse.SetCell(0, 1, 2)
+	sparse.SetCell(2, 3, "-1/3")
+	dense := sequenceMatrix(2, 3)
+	if !sparse.isSparse() || dense.isSparse() {
+		t.Fatal("Wrong sparsity")
+	}
+
+	actual, _ := Kron(sparse, dense)
+	Fail(t).If(intsAreNotEqual(6, actual.RowCount()))
+	Fail(t).If(intsAreNotEqual(12, actual.ColCount()))
+	for idx, v := range actual.Cells() {
+		a := sparse.data[idx.Row/2][idx.Col/3]
+		b := dense.data[idx.Row%2][idx.Col%3]
+		Fail(t).If(rationalsAreNotEqual(new(Rat).Mul(a, b), v))
+	}
+}
+
+func TestKronMixedProductProperty(t *testing.T) {
+	// (A ⊗ B)(C ⊗ D) = (AC) ⊗ (BD)
+	a, b, c, d := sequenceMatrix(2, 3), sequenceMatrix(2, 2), sequenceMatrix(3, 1), unitMatrix(2)
+	ab, _ := Kron(a, b)
+	cd, _ := Kron(c, d)
+	left, _ := ab.Multiply(cd)
+	ac, _ := a.Multiply(c)
+	bd, _ := b.Multiply(d)
+	right, _ := Kron(ac, bd)
+	if !left.Equals(right) {
+		t.Fail()
+	}
+}
+
+func TestDirectSum(t *testing.T) {
+	m, success := DirectSum(unitMatrix(2), unitMatrix(1))
+	if !success || !m.Equals(unitMatrix(3)) {
+		t.Fail()
+	}
+}
+
+func TestVecStacksColumns(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(3, 4)
+	expected := MakeMatrix(4, 1)
+	expected.AddRow(1)
+	expected.AddRow(3)
+	expected.AddRow(2)
+	expected.AddRow(4)
+
+	v, success := m.Vec()
+	if !success || !v.Equals(expected) {
+		t.Fail()
+	}
+	back, _ := v.Unvec(2)
+	if !back.Equals(m) {
+		t.Fail()
+	}
+}
+
+func TestUnvecWithWrongRowCountFails(t *testing.T) {
+	v, _ := sequenceMatrix(2, 3).Vec()
+	if _, success := v.Unvec(4); success {
+		t.Fail()
+	}
+}
+
+func TestCommutationMatrixTransposesVec(t *testing.T) {
+	a := sequenceMatrix(2, 3)
+	vecA, _ := a.Vec()
+	vecAT, _ := a.Transpose().Vec()
+	actual, _ := CommutationMatrix(2, 3).Multiply(vecA)
+	if !actual.Equals(vecAT) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,271 @@
+/*
+	Lazily evaluated matrix expressions.
+*/
+package linear
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	exprLeaf = iota
+	exprAdd
+	exprMultiply
+	exprTranspose
+)
+
+// Expr is a matrix expression which is only computed when Eval is called.
+// Multiply, Add and Transpose build up a graph of the expression; Eval then
+// picks the cheapest order for chains of multiplications, computes repeated
+// subexpressions once, and pushes transposes down to the matrices they apply to.
+type Expr struct {
+	node *exprNode
+}
+
+type exprNode struct {
+	kind       int
+	leaf       Matrix
+	args       []*exprNode
+	rows, cols int
+	valid      bool
+}
+
+// Lazy wraps a matrix so it can be combined into an expression.
+func Lazy(m Matrix) Expr {
+	return Expr{&exprNode{kind: exprLeaf, leaf: m, rows: m.rows, cols: m.cols, valid: !m.IsDegenerate()}}
+}
+
+// RowCount is the number of rows the expression evaluates to.
+func (e Expr) RowCount() int {
+	return e.node.rows
+}
+
+// ColCount is the number of columns the expression evaluates to.
+func (e Expr) ColCount() int {
+	return e.node.cols
+}
+
+// Add the given expression to another expression.
+func (e Expr) Add(e2 Expr) Expr {
+	valid := e.node.valid && e2.node.valid && e.node.rows == e2.node.rows && e.node.cols == e2.node.cols
+	return Expr{&exprNode{kind: exprAdd, args: []*exprNode{e.node, e2.node}, rows: e.node.rows, cols: e.node.cols, valid: valid}}
+}
+
+// Multiply the given expression by another expression.
+func (e Expr) Multiply(e2 Expr) Expr {
+	valid := e.node.valid && e2.node.valid && e.node.cols == e2.node.rows
+	return Expr{&exprNode{kind: exprMultiply, args: []*exprNode{e.node, e2.node}, rows: e.node.rows, cols: e2.node.cols, valid: valid}}
+}
+
+// Transpose the expression.
+func (e Expr) Transpose() Expr {
+	return Expr{&exprNode{kind: exprTranspose, args: []*exprNode{e.node}, rows: e.node.cols, cols: e.node.rows, valid: e.node.valid}}
+}
+
+// Eval computes the value of the expression. It fails if any of the matrices
+// are degenerate or if any operation was given operands of the wrong dimensions.
+func (e Expr) Eval() (Matrix, bool) {
+	if !e.node.valid {
+		return EmptyMatrix(), false
+	}
+	p := newPlanner()
+	return p.eval(p.plan(e.node, false)), true
+}
+
+// Cost is the number of scalar multiplications Eval will perform, after
+// reordering multiplication chains and sharing repeated subexpressions.
+func (e Expr) Cost() int {
+	if !e.node.valid {
+		return 0
+	}
+	p := newPlanner()
+	return p.cost(p.plan(e.node, false), make(map[string]bool))
+}
+
+const (
+	planLeaf = iota
+	planSum
+	planChain
+)
+
+// planNode is the normalized form of an expression: transposes only appear on
+// leaves, and nested sums and products are flattened into single nodes.
+type planNode struct {
+	kind       int
+	leaf       Matrix
+	transposed bool
+	args       []*planNode
+	rows, cols int
+	key        string
+}
+
+type planner struct {
+	nodes  map[string]*planNode
+	values map[string]Matrix
+	splits map[string]int
+}
+
+func newPlanner() *planner {
+	return &planner{make(map[string]*planNode), make(map[string]Matrix), make(map[string]int)}
+}
+
+// intern returns the existing node with the same structure as n, if there is one.
+func (p *planner) intern(n *planNode) *planNode {
+	if existing, ok := p.nodes[n.key]; ok {
+		return existing
+	}
+	p.nodes[n.key] = n
+	return n
+}
+
+func (p *planner) plan(n *exprNode, transposed bool) *planNode {
+	switch n.kind {
+	case exprTranspose:
+		return p.plan(n.args[0], !transposed)
+	case exprAdd:
+		sum := &planNode{kind: planSum}
+		for _, arg := range n.args {
+			sum.args = appendFlattened(sum.args, p.plan(arg, transposed), planSum)
+		}
+		return p.intern(sum.withKey("+"))
+	case exprMultiply:
+		chain := &planNode{kind: planChain}
+		for i := range n.args {
+			arg := n.args[i]
+			if transposed {
+				// (AB)' = B'A'
+				arg = n.args[len(n.args)-1-i]
+			}
+			chain.args = appendFlattened(chain.args, p.plan(arg, transposed), planChain)
+		}
+		return p.intern(chain.withKey("*"))
+	}
+	leaf := &planNode{kind: planLeaf, leaf: n.leaf, transposed: transposed, rows: n.rows, cols: n.cols}
+	leaf.key = fmt.Sprintf("%p/%dx%d", n.leaf.data, n.rows, n.cols)
+	if transposed {
+		leaf.rows, leaf.cols = n.cols, n.rows
+		leaf.key += "'"
+	}
+	return p.intern(leaf)
+}
+
+func appendFlattened(args []*planNode, n *planNode, kind int) []*planNode {
+	if n.kind == kind {
+		return append(args, n.args...)
+	}
+	return append(args, n)
+}
+
+func (n *planNode) withKey(op string) *planNode {
+	keys := make([]string, len(n.args))
+	for i, arg := range n.args {
+		keys[i] = arg.key
+	}
+	n.key = "(" + op + " " + strings.Join(keys, " ") + ")"
+	n.rows, n.cols = n.args[0].rows, n.args[len(n.args)-1].cols
+	return n
+}
+
+// subchain interns the product of args[i..j] of a chain.
+func (p *planner) subchain(chain *planNode, i, j int) *planNode {
+	if i == j {
+		return chain.args[i]
+	}
+	sub := &planNode{kind: planChain, args: chain.args[i : j+1]}
+	return p.intern(sub.withKey("*"))
+}
+
+// split finds where the product of a chain should be divided so that the two
+// halves, and the final product, take the fewest scalar multiplications
+// (the classic matrix-chain ordering).
+func (p *planner) split(chain *planNode) int {
+	if k, ok := p.splits[chain.key]; ok {
+		return k
+	}
+	n := len(chain.args)
+	dims := make([]int, n+1)
+	for i, arg := range chain.args {
+		dims[i] = arg.rows
+	}
+	dims[n] = chain.args[n-1].cols
+
+	cost := make([][]int, n)
+	best := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		best[i] = make([]int, n)
+	}
+	for length := 2; length <= n; length++ {
+		for i := 0; i+length-1 < n; i++ {
+			j := i + length - 1
+			cost[i][j] = -1
+			for k := i; k < j; k++ {
+				c := cost[i][k] + cost[k+1][j] + dims[i]*dims[k+1]*dims[j+1]
+				if cost[i][j] < 0 || c < cost[i][j] {
+					cost[i][j], best[i][j] = c, k
+				}
+			}
+		}
+	}
+	var record func(i, j int)
+	record = func(i, j int) {
+		if i == j {
+			return
+		}
+		k := best[i][j]
+		p.splits[p.subchain(chain, i, j).key] = k - i
+		record(i, k)
+		record(k+1, j)
+	}
+	record(0, n-1)
+	return p.splits[chain.key]
+}
+
+func (p *planner) halves(chain *planNode) (*planNode, *planNode) {
+	k := p.split(chain)
+	return p.subchain(chain, 0, k), p.subchain(chain, k+1, len(chain.args)-1)
+}
+
+func (p *planner) eval(n *planNode) Matrix {
+	if m, ok := p.values[n.key]; ok {
+		return m
+	}
+	var result Matrix
+	switch n.kind {
+	case planLeaf:
+		result = n.leaf
+		if n.transposed {
+			result = n.leaf.Transpose()
+		}
+	case planSum:
+		result = p.eval(n.args[0])
+		for _, arg := range n.args[1:] {
+			result, _ = result.Add(p.eval(arg))
+		}
+	case planChain:
+		left, right := p.halves(n)
+		result, _ = p.eval(left).Multiply(p.eval(right))
+	}
+	p.values[n.key] = result
+	return result
+}
+
+func (p *planner) cost(n *planNode, seen map[string]bool) int {
+	if seen[n.key] {
+		return 0
+	}
+	seen[n.key] = true
+	switch n.kind {
+	case planSum:
+		total := 0
+		for _, arg := range n.args {
+			total += p.cost(arg, seen)
+		}
+		return total
+	case planChain:
+		left, right := p.halves(n)
+		return p.cost(left, seen) + p.cost(right, seen) + left.rows*left.cols*right.cols
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package linear
+
+import (
+	"testing"
+)
+
+func sequenceMatrix(rows, cols int) Matrix {
+	m := ZeroMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.SetCell(i, j, i*cols+j+1)
+		}
+	}
+	return m
+}
+
+func eagerProduct(ms ...Matrix) Matrix {
+	result := ms[0]
+	for _, m := range ms[1:] {
+		result, _ = result.Multiply(m)
+	}
+	return result
+}
+
+func TestLazyLeafEvaluatesToItself(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	actual, success := Lazy(m).Eval()
+	if !success || !actual.Equals(m) {
+		t.Fail()
+	}
+}
+
+func TestLazyChainMatchesEagerProduct(t *testing.T) {
+	a, b, c, d := sequenceMatrix(3, 5), sequenceMatrix(5, 2), sequenceMatrix(2, 6), sequenceMatrix(6, 1)
+	e := Lazy(a).Multiply(Lazy(b)).Multiply(Lazy(c)).Multiply(Lazy(d))
+	actual, success := e.Eval()
+	if !success || !actual.Equals(eagerProduct(a, b, c, d)) {
+		t.Fail()
+	}
+}
+
+func TestLazyChainChoosesCheapestOrder(t *testing.T) {
+	a, b, c := sequenceMatrix(10, 30), sequenceMatrix(30, 5), sequenceMatrix(5, 60)
+	// (AB)C costs 10*30*5 + 10*5*60 = 4500, where A(BC) costs 30*5*60 + 10*30*60 = 27000.
+	e := Lazy(a).Multiply(Lazy(b).Multiply(Lazy(c)))
+	Fail(t).If(intsAreNotEqual(4500, e.Cost()))
+}
+
+func TestLazyCommonSubexpressionsAreCountedOnce(t *testing.T) {
+	a, b := sequenceMatrix(4, 3), sequenceMatrix(3, 4)
+	ab := Lazy(a).Multiply(Lazy(b))
+	e := ab.Add(Lazy(a).Multiply(Lazy(b)))
+	Fail(t).If(intsAreNotEqual(48, e.Cost()))
+
+	expected, _ := eagerProduct(a, b).Add(eagerProduct(a, b))
+	actual, _ := e.Eval()
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestLazyTransposeOfProductIsFused(t *testing.T) {
+	a, b := sequenceMatrix(2, 3), sequenceMatrix(3, 4)
+	e := Lazy(a).Multiply(Lazy(b)).Transpose()
+	expected := eagerProduct(b.Transpose(), a.Transpose())
+	actual, success := e.Eval()
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+	Fail(t).If(intsAreNotEqual(4, actual.RowCount()))
+}
+
+func TestLazyDoubleTransposeCancels(t *testing.T) {
+	a := sequenceMatrix(2, 3)
+	actual, _ := Lazy(a).Transpose().Transpose().Eval()
+	if !actual.Equals(a) {
+		t.Fail()
+	}
+}
+
+func TestLazyTransposeOfSumDistributes(t *testing.T) {
+	a, b := sequenceMatrix(2, 3), unitMatrix(3)
+	ab, _ := a.Multiply(b)
+	expected, _ := ab.Transpose().Add(a.Transpose())
+	actual, _ := Lazy(a).Multiply(Lazy(b)).Add(Lazy(a)).Transpose().Eval()
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestLazyExpressionWithWrongDimensionsFailsToEvaluate(t *testing.T) {
+	_, success := Lazy(sequenceMatrix(2, 3)).Multiply(Lazy(sequenceMatrix(2, 3))).Eval()
+	if success {
+		t.Fail()
+	}
+}
@@ -0,0 +1,117 @@
+/*
+	Exact LDL' factorization of symmetric matrices.
+*/
+package linear
+
+import . "big"
+
+// LDL is a factorization m = L * diag(D) * L' of a symmetric matrix, where L
+// is unit lower triangular. Unlike Cholesky it needs no square roots, so it
+// is exact over the rationals.
+type LDL struct {
+	L Matrix
+	D Vector
+}
+
+// IsSymmetric if the matrix is square and equal to its transpose.
+func (m Matrix) IsSymmetric() bool {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return false
+	}
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < i; j++ {
+			if compareCells(m.data[i][j], m.data[j][i]) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// LDL factors a symmetric matrix without pivoting. It fails if the matrix
+// is not symmetric, or if a zero pivot turns up with nonzero entries below it,
+// which no factorization of this form can handle.
+func (m Matrix) LDL() (LDL, bool) {
+	if !m.IsSymmetric() {
+		return LDL{}, false
+	}
+	n := m.rows
+	l := IdentityMatrix(n)
+	d := ZeroVector(n)
+	for j := 0; j < n; j++ {
+		// D(j) = m(j,j) - sum over k < j of L(j,k)^2 D(k)
+		dj := new(Rat).Set(cellOrZero(m.data[j][j]))
+		for k := 0; k < j; k++ {
+			term := new(Rat).Mul(l.data[j][k], l.data[j][k])
+			dj.Sub(dj, term.Mul(term, d[k]))
+		}
+		d[j] = dj
+		for i := j + 1; i < n; i++ {
+			// L(i,j) = (m(i,j) - sum over k < j of L(i,k) L(j,k) D(k)) / D(j)
+			lij := new(Rat).Set(cellOrZero(m.data[i][j]))
+			for k := 0; k < j; k++ {
+				term := new(Rat).Mul(l.data[i][k], l.data[j][k])
+				lij.Sub(lij, term.Mul(term, d[k]))
+			}
+			if dj.Sign() == 0 {
+				if lij.Sign() != 0 {
+					return LDL{}, false
+				}
+				continue
+			}
+			l.data[i][j] = lij.Quo(lij, dj)
+		}
+	}
+	return LDL{l, d}, true
+}
+
+// IsPositiveDefinite if the matrix is symmetric and x'mx > 0 for every
+// nonzero x, which holds exactly when every pivot of its LDL' factorization
+// is positive.
+func (m Matrix) IsPositiveDefinite() bool {
+	f, ok := m.LDL()
+	if !ok {
+		return false
+	}
+	for _, d := range f.D {
+		if d.Sign() <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Solve finds x such that L * diag(D) * L' * x = b by forward substitution,
+// scaling and back substitution. It fails if some pivot is zero.
+func (f LDL) Solve(b Matrix) (Matrix, bool) {
+	n := f.L.rows
+	if b.IsDegenerate() || b.rows != n {
+		return EmptyMatrix(), false
+	}
+	for _, d := range f.D {
+		if d.Sign() == 0 {
+			return EmptyMatrix(), false
+		}
+	}
+	x := MakeMatrix(b.rows, b.cols)
+	for i, row := range b.data {
+		x.data[i] = make(MatrixRow, b.cols)
+		copyCells(x.data[i], row)
+	}
+	for c := 0; c < b.cols; c++ {
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x.data[i][c] = new(Rat).Sub(x.data[i][c], new(Rat).Mul(f.L.data[i][k], x.data[k][c]))
+			}
+		}
+		for i := 0; i < n; i++ {
+			x.data[i][c] = new(Rat).Quo(x.data[i][c], f.D[i])
+		}
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x.data[i][c] = new(Rat).Sub(x.data[i][c], new(Rat).Mul(f.L.data[k][i], x.data[k][c]))
+			}
+		}
+	}
+	return x, true
+}
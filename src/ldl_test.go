@@ -0,0 +1,121 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func symmetricMatrix() Matrix {
+	m := MakeMatrix(3, 3)
+	m.AddRow(4, 12, -16)
+	m.AddRow(12, 37, -43)
+	m.AddRow(-16, -43, 98)
+	return m
+}
+
+func TestLDLReconstructsMatrix(t *testing.T) {
+	m := symmetricMatrix()
+	f, success := m.LDL()
+	if !success {
+		t.Fatal("LDL failed")
+	}
+	if !f.D.Equals(NewVector(4, 1, 9)) {
+		t.Errorf("Expected D = [4 1 9]; Actual %v", f.D)
+	}
+	d := ZeroMatrix(3, 3)
+	for i, v := range f.D {
+		d.SetCell(i, i, v)
+	}
+	ld, _ := f.L.Multiply(d)
+	product, _ := ld.Multiply(f.L.Transpose())
+	if !product.Equals(m) {
+		product.Print("L D L' = ")
+		t.Error("Factorization does not reconstruct the matrix")
+	}
+}
+
+func TestLDLSolve(t *testing.T) {
+	m := symmetricMatrix()
+	f, _ := m.LDL()
+	b := NewVector(1, 2, 3).ColMatrix()
+	x, success := f.Solve(b)
+	if !success {
+		t.Fatal("Solve failed")
+	}
+	mx, _ := m.Multiply(x)
+	if !mx.Equals(b) {
+		t.Error("Wrong solution")
+	}
+}
+
+func TestLDLOfIndefiniteMatrix(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(2, 1)
+	f, success := m.LDL()
+	if !success {
+		t.Fatal("LDL failed")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(-3, 1), f.D[1]))
+	if m.IsPositiveDefinite() {
+		t.Error("Matrix has a negative eigenvalue")
+	}
+}
+
+func TestLDLFailsOnZeroPivot(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(0, 1)
+	m.AddRow(1, 0)
+	if _, success := m.LDL(); success {
+		t.Error("LDL should fail without pivoting")
+	}
+}
+
+func TestIsPositiveDefinite(t *testing.T) {
+	if !symmetricMatrix().IsPositiveDefinite() {
+		t.Error("Matrix should be positive definite")
+	}
+	semidefinite := MakeMatrix(2, 2)
+	semidefinite.AddRow(1, 1)
+	semidefinite.AddRow(1, 1)
+	if semidefinite.IsPositiveDefinite() {
+		t.Error("Singular matrix is only semidefinite")
+	}
+	upper := MakeMatrix(2, 2)
+	upper.AddRow(1, 1)
+	upper.AddRow(0, 1)
+	if upper.IsPositiveDefinite() {
+		t.Error("Non-symmetric matrix cannot be positive definite")
+	}
+	if nonZeroMatrix(2, 3).IsPositiveDefinite() {
+		t.Error("Non-square matrix cannot be positive definite")
+	}
+}
+
+func TestLDLTreatsUnsetCellsAsZero(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(2)
+	f, success := m.LDL()
+	if !success {
+		t.Fatal("LDL failed")
+	}
+	if !f.D.Equals(NewVector(1, -4)) {
+		t.Errorf("Expected D = [1 -4]; Actual %v", f.D)
+	}
+	if m.IsPositiveDefinite() {
+		t.Error("Matrix is indefinite")
+	}
+	b := MakeMatrix(2, 2)
+	b.AddRow(1)
+	b.AddRow(0, 1)
+	x, success := f.Solve(b)
+	if !success {
+		t.Fatal("Solve failed")
+	}
+	mx, _ := m.Multiply(x)
+	if !mx.Equals(unitMatrix(2)) {
+		t.Errorf("Wrong solution %v", x)
+	}
+}
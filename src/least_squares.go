@@ -0,0 +1,55 @@
+/*
+	Exact least squares solutions of overdetermined systems.
+*/
+package linear
+
+import . "big"
+
+// LeastSquaresSolution is the exact minimizer of the sum of squares of a*x - b.
+type LeastSquaresSolution struct {
+	// X is the minimizer with the smallest norm, which is the only one when
+	// a has full column rank.
+	X Matrix
+	// Residual is b - a*X.
+	Residual Matrix
+	// ResidualNorm2 is the sum of the squares of the entries of Residual.
+	ResidualNorm2 *Rat
+	// Rank is the rank of a.
+	Rank int
+}
+
+// LeastSquares solves the normal equations a'a x = a'b exactly. If a does
+// not have full column rank there are many solutions, and the one with the
+// smallest norm is chosen.
+func LeastSquares(a, b Matrix) (LeastSquaresSolution, bool) {
+	if a.IsDegenerate() || b.IsDegenerate() || a.rows != b.rows || a.IsEmpty() {
+		return LeastSquaresSolution{}, false
+	}
+	at := a.Transpose()
+	normal, _ := at.Multiply(a)
+	rhs, _ := at.Multiply(b)
+
+	reduced, pivots, _ := a.gaussJordan()
+	var x Matrix
+	if len(pivots) == a.cols {
+		x, _ = normal.Solve(rhs)
+	} else {
+		// The nonzero rows r of the reduced matrix span the row space of a,
+		// which is where the smallest solution lies. Writing x = r'y and
+		// multiplying the normal equations through by r leaves a system in y
+		// with an invertible matrix, (a r')'(a r').
+		r, _ := reduced.Submatrix(0, len(pivots), 0, a.cols)
+		rt := r.Transpose()
+		normalRt, _ := normal.Multiply(rt)
+		reducedNormal, _ := r.Multiply(normalRt)
+		reducedRhs, _ := r.Multiply(rhs)
+		y, _ := reducedNormal.Solve(reducedRhs)
+		x, _ = rt.Multiply(y)
+	}
+
+	ax, _ := a.Multiply(x)
+	residual, _ := b.Sub(ax)
+	squares, _ := residual.Hadamard(residual)
+	norm2, _ := squares.Sum()
+	return LeastSquaresSolution{X: x, Residual: residual, ResidualNorm2: norm2, Rank: len(pivots)}, true
+}
@@ -0,0 +1,80 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestLeastSquaresFitsLineExactly(t *testing.T) {
+	// Points (0, 1), (1, 3), (2, 4), (3, 4) give the line y = 3/2 + x.
+	a := MakeMatrix(4, 2)
+	a.AddRow(1, 0)
+	a.AddRow(1, 1)
+	a.AddRow(1, 2)
+	a.AddRow(1, 3)
+	b := NewVector(1, 3, 4, 4).ColMatrix()
+
+	solution, success := LeastSquares(a, b)
+	if !success {
+		t.Fatal("LeastSquares failed")
+	}
+	expected := Vector{NewRat(3, 2), NewRat(1, 1)}.ColMatrix()
+	if !solution.X.Equals(expected) {
+		solution.X.Print("x = ")
+		t.Error("Wrong solution")
+	}
+	residual := Vector{NewRat(-1, 2), NewRat(1, 2), NewRat(1, 2), NewRat(-1, 2)}.ColMatrix()
+	if !solution.Residual.Equals(residual) {
+		t.Error("Wrong residual")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), solution.ResidualNorm2))
+	Fail(t).If(intsAreNotEqual(2, solution.Rank))
+}
+
+func TestLeastSquaresResidualIsOrthogonalToColumnSpace(t *testing.T) {
+	a := sequenceMatrix(5, 3)
+	a.SetCell(4, 2, 20)
+	b := NewVector(1, -2, 0, 7, 3).ColMatrix()
+	solution, _ := LeastSquares(a, b)
+	product, _ := a.Transpose().Multiply(solution.Residual)
+	if !product.Equals(ZeroMatrix(3, 1)) {
+		t.Fail()
+	}
+}
+
+func TestLeastSquaresOfConsistentSystemHasNoResidual(t *testing.T) {
+	a := nonZeroMatrix4x4()
+	x := NewVector(1, -1, 2, 0).ColMatrix()
+	b, _ := a.Multiply(x)
+	solution, _ := LeastSquares(a, b)
+	if !solution.X.Equals(x) || solution.ResidualNorm2.Sign() != 0 {
+		t.Fail()
+	}
+}
+
+func TestLeastSquaresOfRankDeficientMatrixGivesMinimumNorm(t *testing.T) {
+	a := MakeMatrix(3, 2)
+	a.AddRow(1, 1)
+	a.AddRow(1, 1)
+	a.AddRow(1, 1)
+	b := NewVector(1, 2, 3).ColMatrix()
+
+	solution, success := LeastSquares(a, b)
+	if !success {
+		t.Fatal("LeastSquares failed")
+	}
+	// Every x with x1 + x2 = 2 minimizes the residual; (1, 1) is the shortest.
+	if !solution.X.Equals(NewVector(1, 1).ColMatrix()) {
+		solution.X.Print("x = ")
+		t.Error("Expected the minimum norm solution")
+	}
+	Fail(t).If(intsAreNotEqual(1, solution.Rank))
+	Fail(t).If(rationalsAreNotEqual(NewRat(2, 1), solution.ResidualNorm2))
+}
+
+func TestLeastSquaresWithMismatchedRowsFails(t *testing.T) {
+	if _, success := LeastSquares(unitMatrix(3), unitMatrix(2)); success {
+		t.Fail()
+	}
+}
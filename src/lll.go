@@ -0,0 +1,119 @@
+/*
+	Lenstra-Lenstra-Lovász lattice basis reduction.
+*/
+package linear
+
+import . "big"
+
+// LLLReduction is a reduced basis for the lattice spanned by the rows of a
+// matrix, together with the unimodular matrix taking the original basis to
+// it: Basis = Transform * m.
+type LLLReduction struct {
+	Basis, Transform Matrix
+}
+
+// LLL reduces the lattice basis given by the rows of an integer matrix,
+// which must be linearly independent. delta, in (1/4, 1], sets how much
+// shorter each Gram-Schmidt vector must be kept relative to the one before;
+// 3/4 is the usual choice, and values nearer 1 give shorter bases more slowly.
+// The Gram-Schmidt coefficients are kept exactly as rationals.
+func (m Matrix) LLL(delta *Rat) (LLLReduction, bool) {
+	if !m.IsInteger() || delta.Cmp(NewRat(1, 4)) <= 0 || delta.Cmp(NewRat(1, 1)) > 0 {
+		return LLLReduction{}, false
+	}
+	b, t := MakeMatrix(m.rows, m.cols), IdentityMatrix(m.rows)
+	for i, row := range m.data {
+		b.data[i] = make(MatrixRow, m.cols)
+		copyCells(b.data[i], row)
+	}
+	mu, norms, ok := b.gramSchmidtRows()
+	if !ok {
+		return LLLReduction{}, false
+	}
+
+	for k := 1; k < b.rows; {
+		// Size reduction: make |mu(k, j)| <= 1/2 for every j < k.
+		for j := k - 1; j >= 0; j-- {
+			q := new(Rat).SetInt(roundRat(mu.data[k][j]))
+			if q.Sign() == 0 {
+				continue
+			}
+			b.subtractRowMultiple(k, j, q)
+			t.subtractRowMultiple(k, j, q)
+			mu.subtractRowMultiple(k, j, q)
+		}
+
+		// Lovász condition: |b*(k)|^2 >= (delta - mu(k, k-1)^2) |b*(k-1)|^2.
+		bound := new(Rat).Mul(mu.data[k][k-1], mu.data[k][k-1])
+		bound.Sub(delta, bound)
+		bound.Mul(bound, norms[k-1])
+		if norms[k].Cmp(bound) >= 0 {
+			k++
+			continue
+		}
+		b.Swap(k, k-1)
+		t.Swap(k, k-1)
+		swapGramSchmidt(mu, norms, k)
+		if k > 1 {
+			k--
+		}
+	}
+	return LLLReduction{b, t}, true
+}
+
+// gramSchmidtRows orthogonalizes the rows of the matrix, giving the lower
+// triangular coefficients mu, with row i the sum of mu(i, j) times
+// orthogonal vector j, and the squared norms of the orthogonal vectors.
+// It fails if the rows are dependent.
+func (m Matrix) gramSchmidtRows() (mu Matrix, norms []*Rat, ok bool) {
+	rows := make([]Vector, m.rows)
+	for i := range rows {
+		rows[i], _ = m.Row(i)
+	}
+	us, r, _ := GramSchmidtWithCoefficients(rows)
+	norms = make([]*Rat, len(us))
+	for i, u := range us {
+		if u.IsZero() {
+			return EmptyMatrix(), nil, false
+		}
+		norms[i] = u.Norm2Squared()
+	}
+	return r.Transpose(), norms, true
+}
+
+// swapGramSchmidt updates the coefficients and squared norms from
+// gramSchmidtRows after rows k-1 and k of the basis have been exchanged.
+// Only those two orthogonal vectors change, so the update takes O(n) steps
+// where orthogonalizing again would take O(n³).
+func swapGramSchmidt(mu Matrix, norms []*Rat, k int) {
+	m := new(Rat).Set(mu.data[k][k-1])
+	norm := new(Rat).Mul(m, m)
+	norm.Mul(norm, norms[k-1])
+	norm.Add(norm, norms[k])
+	mu.data[k][k-1] = new(Rat).Quo(new(Rat).Mul(m, norms[k-1]), norm)
+	norms[k] = new(Rat).Quo(new(Rat).Mul(norms[k-1], norms[k]), norm)
+	norms[k-1] = norm
+
+	for j := 0; j < k-1; j++ {
+		mu.data[k-1][j], mu.data[k][j] = mu.data[k][j], mu.data[k-1][j]
+	}
+	for i := k + 1; i < mu.rows; i++ {
+		t := mu.data[i][k]
+		mu.data[i][k] = new(Rat).Sub(mu.data[i][k-1], new(Rat).Mul(m, t))
+		mu.data[i][k-1] = new(Rat).Add(t, new(Rat).Mul(mu.data[k][k-1], mu.data[i][k]))
+	}
+}
+
+// subtractRowMultiple subtracts k times row src from row dst.
+func (m Matrix) subtractRowMultiple(dst, src int, k *Rat) {
+	for j := range m.data[dst] {
+		m.data[dst][j] = new(Rat).Sub(m.data[dst][j], new(Rat).Mul(k, m.data[src][j]))
+	}
+}
+
+// roundRat is the nearest integer to x, rounding halves up.
+func roundRat(x *Rat) *Int {
+	num := new(Int).Mul(x.Num(), NewInt(2))
+	num.Add(num, x.Denom())
+	return num.Div(num, new(Int).Mul(x.Denom(), NewInt(2)))
+}
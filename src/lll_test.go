@@ -0,0 +1,137 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestLLLReducesBasis(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(1, 1, 1)
+	m.AddRow(-1, 0, 2)
+	m.AddRow(3, 5, 6)
+	f, success := m.LLL(NewRat(3, 4))
+	if !success {
+		t.Fatal("LLL failed")
+	}
+	expected := MakeMatrix(3, 3)
+	expected.AddRow(0, 1, 0)
+	expected.AddRow(1, 0, 1)
+	expected.AddRow(-2, 0, 1)
+	if !f.Basis.Equals(expected) {
+		f.Basis.Print("reduced = ")
+		t.Error("Wrong reduced basis")
+	}
+	tm, _ := f.Transform.Multiply(m)
+	if !tm.Equals(f.Basis) {
+		t.Error("Transform * m should equal the reduced basis")
+	}
+	assertUnimodular(t, "Transform", f.Transform)
+}
+
+func TestLLLSatisfiesReductionConditions(t *testing.T) {
+	m := MakeMatrix(4, 4)
+	m.AddRow(105, 821, 404, 328)
+	m.AddRow(881, 667, 644, 927)
+	m.AddRow(181, 483, 87, 500)
+	m.AddRow(893, 834, 732, 441)
+	delta := NewRat(99, 100)
+	f, success := m.LLL(delta)
+	if !success {
+		t.Fatal("LLL failed")
+	}
+	tm, _ := f.Transform.Multiply(m)
+	if !tm.Equals(f.Basis) {
+		t.Error("Transform * m should equal the reduced basis")
+	}
+	assertUnimodular(t, "Transform", f.Transform)
+
+	mu, norms, _ := f.Basis.gramSchmidtRows()
+	half := NewRat(1, 2)
+	for i := 1; i < 4; i++ {
+		for j := 0; j < i; j++ {
+			if new(Rat).Abs(mu.data[i][j]).Cmp(half) > 0 {
+				t.Errorf("Coefficient (%d, %d) = %v is not size reduced", i, j, mu.data[i][j])
+			}
+		}
+		bound := new(Rat).Mul(mu.data[i][i-1], mu.data[i][i-1])
+		bound.Sub(delta, bound)
+		bound.Mul(bound, norms[i-1])
+		if norms[i].Cmp(bound) < 0 {
+			t.Errorf("Lovász condition fails at %d", i)
+		}
+	}
+}
+
+func TestSwapGramSchmidtMatchesRecomputing(t *testing.T) {
+	m := MakeMatrix(4, 4)
+	m.AddRow(105, 821, 404, 328)
+	m.AddRow(881, 667, 644, 927)
+	m.AddRow(181, 483, 87, 500)
+	m.AddRow(893, 834, 732, 441)
+	for k := 1; k < 4; k++ {
+		mu, norms, _ := m.gramSchmidtRows()
+		swapped := m.clone()
+		swapped.Swap(k, k-1)
+		swapGramSchmidt(mu, norms, k)
+		expectedMu, expectedNorms, _ := swapped.gramSchmidtRows()
+		if !mu.Equals(expectedMu) {
+			t.Errorf("Wrong coefficients after swapping rows %d and %d", k-1, k)
+		}
+		for i := range norms {
+			Fail(t).If(rationalsAreNotEqual(expectedNorms[i], norms[i]))
+		}
+	}
+}
+
+func TestLLLTreatsUnsetCellsAsZero(t *testing.T) {
+	partial := MakeMatrix(3, 3)
+	partial.AddRow(1, 1, 1)
+	partial.AddRow(-1)
+	partial.AddRow(3, 5, 6)
+	full := MakeMatrix(3, 3)
+	full.AddRow(1, 1, 1)
+	full.AddRow(-1, 0, 0)
+	full.AddRow(3, 5, 6)
+	f, success := partial.LLL(NewRat(3, 4))
+	expected, _ := full.LLL(NewRat(3, 4))
+	if !success || !f.Basis.Equals(expected.Basis) {
+		t.Error("Wrong reduced basis")
+	}
+}
+
+func TestLLLFailsForDependentRows(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(2, 4)
+	if _, success := m.LLL(NewRat(3, 4)); success {
+		t.Error("LLL should fail")
+	}
+}
+
+func TestLLLRejectsBadDelta(t *testing.T) {
+	if _, success := unitMatrix(2).LLL(NewRat(1, 4)); success {
+		t.Error("delta must exceed 1/4")
+	}
+	if _, success := unitMatrix(2).LLL(NewRat(3, 2)); success {
+		t.Error("delta must not exceed 1")
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	for _, c := range []struct {
+		x        *Rat
+		expected int64
+	}{
+		{NewRat(7, 2), 4},
+		{NewRat(-7, 2), -3},
+		{NewRat(5, 3), 2},
+		{NewRat(-5, 3), -2},
+		{NewRat(3, 1), 3},
+	} {
+		if r := roundRat(c.x); r.Int64() != c.expected {
+			t.Errorf("round(%v): Expected %d; Actual %v", c.x, c.expected, r)
+		}
+	}
+}
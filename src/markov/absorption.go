@@ -0,0 +1,155 @@
+/*
+	Long run behaviour: stationary distributions, absorption and hitting times.
+*/
+package markov
+
+import (
+	"linear"
+)
+
+import . "big"
+
+// StationaryDistributions finds, for each recurrent class, the stationary
+// distribution concentrated on that class. Every stationary distribution
+// of the chain is a mixture of these.
+func (c *Chain) StationaryDistributions() []linear.Vector {
+	recurrent, _ := c.recurrentClasses()
+	dists := make([]linear.Vector, len(recurrent))
+	for i, k := range recurrent {
+		dists[i] = linear.ZeroVector(c.n)
+		for j, v := range c.classStationary(k.States) {
+			dists[i][k.States[j]] = v
+		}
+	}
+	return dists
+}
+
+// classStationary solves pi P = pi with the entries of pi summing to one,
+// restricted to a closed class. Since the class is irreducible the solution
+// is unique, so stacking the normalization under (P' - I) pi = 0 gives a
+// consistent system with exactly one solution.
+func (c *Chain) classStationary(states []int) linear.Vector {
+	n := len(states)
+	p := c.submatrix(states, states)
+	balance, _ := p.Transpose().Sub(linear.IdentityMatrix(n))
+	ones := linear.ZeroVector(n)
+	for i := range ones {
+		ones[i] = NewRat(1, 1)
+	}
+	system, _ := linear.VStack(balance, ones.RowMatrix())
+	rhs := linear.ZeroVector(n + 1)
+	rhs[n] = NewRat(1, 1)
+	pi, _ := system.Solve(rhs.ColMatrix())
+	result, _ := pi.Col(0)
+	return result
+}
+
+// Stationary is the stationary distribution of a chain with a single
+// recurrent class; with more than one there are many, and it fails with ErrNotUnique.
+func (c *Chain) Stationary() (linear.Vector, error) {
+	dists := c.StationaryDistributions()
+	if len(dists) != 1 {
+		return nil, ErrNotUnique
+	}
+	return dists[0], nil
+}
+
+// Absorption describes how the chain leaves its transient states. Rows of N
+// and B, and entries of Steps, follow the order of Transient; columns of B
+// follow Recurrent.
+type Absorption struct {
+	Transient []int
+	Recurrent []Class
+	// N is the fundamental matrix (I - Q)⁻¹, where Q holds the transitions
+	// among transient states: N(i, j) is the expected number of visits to
+	// transient state j starting from transient state i.
+	N linear.Matrix
+	// B holds the probabilities of ending up in each recurrent class: the
+	// row sums of N R, where R holds the transitions from transient to
+	// recurrent states.
+	B linear.Matrix
+	// Steps are the expected numbers of steps spent among the transient
+	// states before reaching a recurrent class, the row sums of N.
+	Steps linear.Vector
+}
+
+// Absorption computes the absorption probabilities and expected times of
+// the chain. With no transient states the matrices are empty.
+func (c *Chain) Absorption() Absorption {
+	recurrent, transient := c.recurrentClasses()
+	a := Absorption{Transient: transient, Recurrent: recurrent}
+	if len(transient) == 0 {
+		a.N, a.B = linear.EmptyMatrix(), linear.EmptyMatrix()
+		return a
+	}
+
+	q := c.submatrix(transient, transient)
+	iq, _ := linear.IdentityMatrix(len(transient)).Sub(q)
+	// Every transient state eventually leaves, so I - Q is invertible.
+	a.N, _ = iq.Inverse()
+
+	r := linear.ZeroMatrix(len(transient), len(recurrent))
+	for j, k := range recurrent {
+		for i, t := range transient {
+			sum := new(Rat)
+			for _, s := range k.States {
+				sum.Add(sum, c.p.Cell(t, s))
+			}
+			r.SetCell(i, j, sum)
+		}
+	}
+	a.B, _ = a.N.Multiply(r)
+
+	a.Steps = make(linear.Vector, len(transient))
+	for i := range a.Steps {
+		row, _ := a.N.Row(i)
+		a.Steps[i] = row.Norm1()
+	}
+	return a
+}
+
+// HittingTimes are the expected numbers of steps for the chain to first
+// reach one of the target states, from each state. They are zero for the
+// targets themselves and nil where the chain may never reach them.
+func (c *Chain) HittingTimes(targets ...int) ([]*Rat, error) {
+	isTarget := make([]bool, c.n)
+	for _, t := range targets {
+		if t < 0 || t >= c.n {
+			return nil, ErrBadState
+		}
+		isTarget[t] = true
+	}
+
+	// Make the targets absorbing; the hitting time is then the time to
+	// absorption for each state certain to be absorbed into a target.
+	p := linear.ZeroMatrix(c.n, c.n)
+	for i := 0; i < c.n; i++ {
+		for j := 0; j < c.n; j++ {
+			switch {
+			case !isTarget[i]:
+				p.SetCell(i, j, c.p.Cell(i, j))
+			case i == j:
+				p.SetCell(i, j, 1)
+			}
+		}
+	}
+	modified := &Chain{p, c.n}
+	a := modified.Absorption()
+
+	times := make([]*Rat, c.n)
+	for _, t := range targets {
+		times[t] = new(Rat)
+	}
+	for i, s := range a.Transient {
+		certain := new(Rat)
+		for j, k := range a.Recurrent {
+			if isTarget[k.States[0]] {
+				certain.Add(certain, a.B.Cell(i, j))
+			}
+		}
+		if certain.Cmp(NewRat(1, 1)) == 0 {
+			times[s] = a.Steps[i]
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,121 @@
+/*
+	Package markov analyses discrete time Markov chains with exact rational
+	transition probabilities.
+*/
+package markov
+
+import (
+	"errors"
+	"fmt"
+	"linear"
+)
+
+import . "big"
+
+var (
+	ErrNotSquare    = errors.New("markov: transition matrix is not square")
+	ErrDegenerate   = errors.New("markov: transition matrix is not completely filled in")
+	ErrNotUnique    = errors.New("markov: chain has more than one stationary distribution")
+	ErrBadState     = errors.New("markov: state out of range")
+	ErrDistribution = errors.New("markov: not a probability distribution over the states")
+)
+
+// RowError reports a row of a transition matrix which is not a probability distribution.
+type RowError struct {
+	Row int
+	Msg string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("markov: row %d %s", e.Row, e.Msg)
+}
+
+// Chain is a Markov chain whose transition matrix P gives in cell (i, j) the
+// probability of moving from state i to state j in one step.
+type Chain struct {
+	p linear.Matrix
+	n int
+}
+
+// Validate checks that p is a stochastic matrix: square, with nonnegative
+// entries and every row summing to one.
+func Validate(p linear.Matrix) error {
+	if p.IsDegenerate() {
+		return ErrDegenerate
+	}
+	if p.RowCount() != p.ColCount() || p.IsEmpty() {
+		return ErrNotSquare
+	}
+	one := NewRat(1, 1)
+	for i := 0; i < p.RowCount(); i++ {
+		sum := new(Rat)
+		for j := 0; j < p.ColCount(); j++ {
+			v := p.Cell(i, j)
+			if v == nil {
+				return &RowError{i, fmt.Sprintf("has unset entry in column %d", j)}
+			}
+			if v.Sign() < 0 {
+				return &RowError{i, fmt.Sprintf("has negative probability %s in column %d", v.RatString(), j)}
+			}
+			sum.Add(sum, v)
+		}
+		if sum.Cmp(one) != 0 {
+			return &RowError{i, "sums to " + sum.RatString()}
+		}
+	}
+	return nil
+}
+
+// New creates a chain from a stochastic matrix, which it validates first.
+// The chain keeps its own copy, so later changes to p do not affect it.
+func New(p linear.Matrix) (*Chain, error) {
+	if err := Validate(p); err != nil {
+		return nil, err
+	}
+	n := p.RowCount()
+	own, _ := p.Submatrix(0, n, 0, n)
+	return &Chain{own, n}, nil
+}
+
+// States is the number of states in the chain.
+func (c *Chain) States() int {
+	return c.n
+}
+
+// Transition is the probability of moving from state i to state j in one step.
+func (c *Chain) Transition(i, j int) *Rat {
+	return c.p.Cell(i, j)
+}
+
+// Distribution is the distribution over the states after the given number
+// of steps, starting from the initial distribution.
+func (c *Chain) Distribution(initial linear.Vector, steps int) (linear.Vector, error) {
+	if len(initial) != c.n || steps < 0 {
+		return nil, ErrDistribution
+	}
+	sum := new(Rat)
+	for _, v := range initial {
+		if v.Sign() < 0 {
+			return nil, ErrDistribution
+		}
+		sum.Add(sum, v)
+	}
+	if sum.Cmp(NewRat(1, 1)) != 0 {
+		return nil, ErrDistribution
+	}
+	power, _ := c.p.Pow(steps)
+	dist, _ := initial.RowMatrix().Multiply(power)
+	result, _ := dist.Row(0)
+	return result, nil
+}
+
+// submatrix picks out the given rows and columns of the transition matrix.
+func (c *Chain) submatrix(rows, cols []int) linear.Matrix {
+	m := linear.MakeMatrix(len(rows), len(cols))
+	for i, r := range rows {
+		for j, col := range cols {
+			m.SetCell(i, j, c.p.Cell(r, col))
+		}
+	}
+	return m
+}
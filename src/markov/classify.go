@@ -0,0 +1,200 @@
+/*
+	Classification of the states of a chain.
+*/
+package markov
+
+import (
+	"sort"
+)
+
+import . "big"
+
+// Class is a communicating class: a maximal set of states each reachable
+// from every other. A class is recurrent if the chain can never leave it,
+// and transient otherwise. Period is the greatest common divisor of the
+// lengths of the paths from a state of the class back to itself, or zero if
+// there are no such paths.
+type Class struct {
+	States    []int
+	Recurrent bool
+	Period    int
+}
+
+// StateKind is the classification of a single state.
+type StateKind int
+
+const (
+	Transient StateKind = iota
+	Recurrent
+	// Absorbing states are recurrent states the chain never leaves.
+	Absorbing
+)
+
+func (k StateKind) String() string {
+	switch k {
+	case Transient:
+		return "transient"
+	case Recurrent:
+		return "recurrent"
+	}
+	return "absorbing"
+}
+
+// successors lists the states reachable in one step from each state.
+func (c *Chain) successors() [][]int {
+	next := make([][]int, c.n)
+	for i := range next {
+		for j := 0; j < c.n; j++ {
+			if c.p.Cell(i, j).Sign() > 0 {
+				next[i] = append(next[i], j)
+			}
+		}
+	}
+	return next
+}
+
+// reachable marks the states reachable from each state in zero or more steps.
+func (c *Chain) reachable() [][]bool {
+	next := c.successors()
+	reach := make([][]bool, c.n)
+	for s := range reach {
+		reach[s] = make([]bool, c.n)
+		reach[s][s] = true
+		stack := []int{s}
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, j := range next[i] {
+				if !reach[s][j] {
+					reach[s][j] = true
+					stack = append(stack, j)
+				}
+			}
+		}
+	}
+	return reach
+}
+
+// Classes partitions the states into communicating classes, ordered by their smallest state.
+func (c *Chain) Classes() []Class {
+	reach := c.reachable()
+	next := c.successors()
+	class := make([]int, c.n)
+	for i := range class {
+		class[i] = -1
+	}
+
+	var classes []Class
+	for s := 0; s < c.n; s++ {
+		if class[s] >= 0 {
+			continue
+		}
+		k := Class{Recurrent: true}
+		for t := s; t < c.n; t++ {
+			if reach[s][t] && reach[t][s] {
+				class[t] = len(classes)
+				k.States = append(k.States, t)
+			}
+		}
+		for _, i := range k.States {
+			for _, j := range next[i] {
+				if !reach[j][s] {
+					k.Recurrent = false
+				}
+			}
+		}
+		k.Period = period(k.States, next, class)
+		classes = append(classes, k)
+	}
+	return classes
+}
+
+// period finds the period of a class from a breadth first search of it: every
+// edge u -> v within the class closes cycles whose lengths differ from
+// depth(u) + 1 - depth(v) by multiples of the period.
+func period(states []int, next [][]int, class []int) int {
+	depth := map[int]int{states[0]: 0}
+	queue := []int{states[0]}
+	g := 0
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range next[u] {
+			if class[v] != class[u] {
+				continue
+			}
+			if d, seen := depth[v]; seen {
+				g = gcd(g, depth[u]+1-d)
+				continue
+			}
+			depth[v] = depth[u] + 1
+			queue = append(queue, v)
+		}
+	}
+	return g
+}
+
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Classify gives the kind of each state.
+func (c *Chain) Classify() []StateKind {
+	kinds := make([]StateKind, c.n)
+	for _, k := range c.Classes() {
+		for _, s := range k.States {
+			switch {
+			case !k.Recurrent:
+				kinds[s] = Transient
+			case c.IsAbsorbing(s):
+				kinds[s] = Absorbing
+			default:
+				kinds[s] = Recurrent
+			}
+		}
+	}
+	return kinds
+}
+
+// IsAbsorbing if the chain stays in the state forever once it gets there.
+// It is false for a state out of range.
+func (c *Chain) IsAbsorbing(state int) bool {
+	if state < 0 || state >= c.n {
+		return false
+	}
+	return c.p.Cell(state, state).Cmp(NewRat(1, 1)) == 0
+}
+
+// IsIrreducible if every state can be reached from every other.
+func (c *Chain) IsIrreducible() bool {
+	return len(c.Classes()) == 1
+}
+
+// IsErgodic if the chain is irreducible and aperiodic, so that its
+// distribution converges to the stationary distribution from any start.
+func (c *Chain) IsErgodic() bool {
+	classes := c.Classes()
+	return len(classes) == 1 && classes[0].Period == 1
+}
+
+// recurrentClasses and transientStates split the states up for the absorption analysis.
+func (c *Chain) recurrentClasses() (recurrent []Class, transient []int) {
+	for _, k := range c.Classes() {
+		if k.Recurrent {
+			recurrent = append(recurrent, k)
+		} else {
+			transient = append(transient, k.States...)
+		}
+	}
+	sort.Ints(transient)
+	return recurrent, transient
+}
@@ -0,0 +1,200 @@
+package markov
+
+import (
+	"errors"
+	"linear"
+	"testing"
+)
+
+import . "big"
+
+// matrix builds a matrix from rows of fractions written as numerator, denominator pairs.
+func matrix(rows ...[]int64) linear.Matrix {
+	m := linear.MakeMatrix(len(rows), len(rows[0])/2)
+	for i, row := range rows {
+		for j := 0; j < len(row); j += 2 {
+			m.SetCell(i, j/2, NewRat(row[j], row[j+1]))
+		}
+	}
+	return m
+}
+
+func mustChain(t *testing.T, p linear.Matrix) *Chain {
+	c, err := New(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func gamblersRuin(t *testing.T) *Chain {
+	return mustChain(t, matrix(
+		[]int64{1, 1, 0, 1, 0, 1, 0, 1},
+		[]int64{1, 2, 0, 1, 1, 2, 0, 1},
+		[]int64{0, 1, 1, 2, 0, 1, 1, 2},
+		[]int64{0, 1, 0, 1, 0, 1, 1, 1},
+	))
+}
+
+func assertVector(t *testing.T, expected, actual linear.Vector) {
+	if !expected.Equals(actual) {
+		t.Errorf("Expected %v; Actual %v", expected, actual)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var rowErr *RowError
+	err := Validate(matrix([]int64{1, 2, 1, 3}, []int64{1, 2, 1, 2}))
+	if !errors.As(err, &rowErr) || rowErr.Row != 0 {
+		t.Errorf("Expected row 0 to be reported; Actual %v", err)
+	}
+	err = Validate(matrix([]int64{3, 2, -1, 2}, []int64{1, 2, 1, 2}))
+	if !errors.As(err, &rowErr) {
+		t.Errorf("Expected negative entry to be reported; Actual %v", err)
+	}
+	partial := linear.MakeMatrix(2, 2)
+	partial.AddRow(1, 0)
+	partial.AddRow(1)
+	err = Validate(partial)
+	if !errors.As(err, &rowErr) || rowErr.Row != 1 {
+		t.Errorf("Expected unset entry in row 1 to be reported; Actual %v", err)
+	}
+	if err := Validate(matrix([]int64{1, 2, 1, 2})); err != ErrNotSquare {
+		t.Errorf("Expected ErrNotSquare; Actual %v", err)
+	}
+	if _, err := New(linear.MakeMatrix(2, 2)); err != ErrDegenerate {
+		t.Errorf("Expected ErrDegenerate; Actual %v", err)
+	}
+}
+
+func TestChainKeepsItsOwnCopy(t *testing.T) {
+	p := matrix([]int64{1, 2, 1, 2}, []int64{1, 1, 0, 1})
+	c := mustChain(t, p)
+	p.SetCell(0, 0, 5)
+	if c.Transition(0, 0).Cmp(NewRat(1, 2)) != 0 {
+		t.Errorf("Changing p changed the chain; Actual %v", c.Transition(0, 0))
+	}
+}
+
+func TestStationaryDistribution(t *testing.T) {
+	c := mustChain(t, matrix(
+		[]int64{1, 2, 1, 2},
+		[]int64{1, 4, 3, 4},
+	))
+	pi, err := c.Stationary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertVector(t, linear.Vector{NewRat(1, 3), NewRat(2, 3)}, pi)
+	if !c.IsErgodic() {
+		t.Error("Chain should be ergodic")
+	}
+	later, _ := c.Distribution(pi, 5)
+	assertVector(t, pi, later)
+}
+
+func TestStationaryIsNotUniqueWithTwoRecurrentClasses(t *testing.T) {
+	c := gamblersRuin(t)
+	if _, err := c.Stationary(); err != ErrNotUnique {
+		t.Errorf("Expected ErrNotUnique; Actual %v", err)
+	}
+	dists := c.StationaryDistributions()
+	if len(dists) != 2 {
+		t.Fatalf("Expected one distribution per absorbing state; Actual %v", dists)
+	}
+	assertVector(t, linear.NewVector(1, 0, 0, 0), dists[0])
+	assertVector(t, linear.NewVector(0, 0, 0, 1), dists[1])
+}
+
+func TestClassify(t *testing.T) {
+	c := gamblersRuin(t)
+	kinds := c.Classify()
+	expected := []StateKind{Absorbing, Transient, Transient, Absorbing}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Errorf("State %d: Expected %v; Actual %v", i, expected[i], kinds[i])
+		}
+	}
+	if c.IsAbsorbing(-1) || c.IsAbsorbing(4) {
+		t.Error("States out of range should not be absorbing")
+	}
+	classes := c.Classes()
+	if len(classes) != 3 || len(classes[1].States) != 2 || classes[1].Recurrent {
+		t.Errorf("Expected states 1 and 2 to form a transient class; Actual %v", classes)
+	}
+	if classes[1].Period != 2 {
+		t.Errorf("Expected period 2; Actual %d", classes[1].Period)
+	}
+}
+
+func TestPeriodicChain(t *testing.T) {
+	c := mustChain(t, matrix(
+		[]int64{0, 1, 1, 2, 1, 2},
+		[]int64{1, 1, 0, 1, 0, 1},
+		[]int64{1, 1, 0, 1, 0, 1},
+	))
+	classes := c.Classes()
+	if len(classes) != 1 || !classes[0].Recurrent || classes[0].Period != 2 {
+		t.Errorf("Expected one recurrent class of period 2; Actual %v", classes)
+	}
+	if c.IsErgodic() || !c.IsIrreducible() {
+		t.Error("Chain is irreducible but periodic")
+	}
+	if c.Classify()[0] != Recurrent {
+		t.Error("State 0 should be recurrent")
+	}
+}
+
+func TestAbsorption(t *testing.T) {
+	a := gamblersRuin(t).Absorption()
+	if len(a.Transient) != 2 || a.Transient[0] != 1 || a.Transient[1] != 2 {
+		t.Fatalf("Expected transient states [1 2]; Actual %v", a.Transient)
+	}
+	n := matrix([]int64{4, 3, 2, 3}, []int64{2, 3, 4, 3})
+	if !a.N.Equals(n) {
+		a.N.Print("N = ")
+		t.Error("Wrong fundamental matrix")
+	}
+	b := matrix([]int64{2, 3, 1, 3}, []int64{1, 3, 2, 3})
+	if !a.B.Equals(b) {
+		a.B.Print("B = ")
+		t.Error("Wrong absorption probabilities")
+	}
+	assertVector(t, linear.NewVector(2, 2), a.Steps)
+}
+
+func TestHittingTimes(t *testing.T) {
+	cycle := mustChain(t, matrix(
+		[]int64{0, 1, 1, 1, 0, 1},
+		[]int64{0, 1, 0, 1, 1, 1},
+		[]int64{1, 1, 0, 1, 0, 1},
+	))
+	times, err := cycle.HittingTimes(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertVector(t, linear.NewVector(0, 2, 1), times)
+
+	times, _ = gamblersRuin(t).HittingTimes(0, 3)
+	assertVector(t, linear.NewVector(0, 2, 2, 0), times)
+
+	times, _ = gamblersRuin(t).HittingTimes(3)
+	if times[1] != nil || times[0] != nil {
+		t.Errorf("State 3 may never be reached from 0 or 1; Actual %v", times)
+	}
+	if _, err := cycle.HittingTimes(5); err != ErrBadState {
+		t.Errorf("Expected ErrBadState; Actual %v", err)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	c := gamblersRuin(t)
+	dist, err := c.Distribution(linear.NewVector(0, 1, 0, 0), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertVector(t, linear.Vector{NewRat(1, 2), NewRat(1, 4), new(Rat), NewRat(1, 4)}, dist)
+	if _, err := c.Distribution(linear.NewVector(1, 1, 0, 0), 1); err != ErrDistribution {
+		t.Errorf("Expected ErrDistribution; Actual %v", err)
+	}
+}
@@ -93,6 +93,27 @@ func (m Matrix) SetCell(row, col int, i interface{}) bool {
 	return success
 }
 
+// RowCount is the number of rows in the matrix.
+func (m Matrix) RowCount() int {
+	return m.rows
+}
+
+// ColCount is the number of columns in the matrix.
+func (m Matrix) ColCount() int {
+	return m.cols
+}
+
+// Cell returns a copy of the value at row, col, or nil if the cell is out of range or unset.
+func (m Matrix) Cell(row, col int) *Rat {
+	if 0 > row || row >= m.rows || 0 > col || col >= m.cols {
+		return nil
+	}
+	if len(m.data[row]) == 0 || m.data[row][col] == nil {
+		return nil
+	}
+	return new(Rat).Set(m.data[row][col])
+}
+
 // IsEmpty if number of rows or columns is 0.
 func (m Matrix) IsEmpty() bool {
 	return m.cols == 0 || m.rows == 0
@@ -111,6 +132,31 @@ func ZeroMatrix(rows, cols int) Matrix {
 	return m
 }
 
+// IdentityMatrix creates an NxN matrix with ones on the diagonal and zeros elsewhere.
+func IdentityMatrix(n int) Matrix {
+	m := ZeroMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.data[i][i] = NewRat(1, 1)
+	}
+	return m
+}
+
+func (m Matrix) clone() Matrix {
+	result := MakeMatrix(m.rows, m.cols)
+	for i, row := range m.data {
+		if row == nil {
+			continue
+		}
+		result.data[i] = make(MatrixRow, len(row))
+		for j, v := range row {
+			if v != nil {
+				result.data[i][j] = new(Rat).Set(v)
+			}
+		}
+	}
+	return result
+}
+
 func (m Matrix) print(w io.Writer, title string) {
 	fmt.Fprintf(w, "Printing Matrix %v (%v rows, %v cols):\n", title, m.rows, m.cols)
 	for i := 0; i < m.rows; i++ {
@@ -131,8 +177,8 @@ func (m Matrix) hasSameDimension(m2 Matrix) bool {
 	return m.cols == m2.cols && m.rows == m2.rows
 }
 
-func (m Matrix) hasComplementaryDimension(m2 Matrix) bool {
-	return m.cols == m2.rows && m.rows == m2.cols
+func (m Matrix) canMultiply(m2 Matrix) bool {
+	return m.cols == m2.rows
 }
 
 // IsDegenerate if not all rows or columns are filled in.
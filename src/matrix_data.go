@@ -11,36 +11,11 @@ type MatrixRow []*Rat
 // MatrixData is an array of MatrixRow objects.
 type MatrixData []MatrixRow
 
-// Iter iterates through rows
-func (md MatrixData) Iter() <-chan interface{} {
-	ch := make(chan interface{})
-	go func() {
-		for _, e := range md {
-			ch <- e
-		}
-		close(ch)
-	}()
-	return ch
-}
-
-// Iter iterates through values
-func (mr MatrixRow) Iter() <-chan interface{} {
-	ch := make(chan interface{})
-	go func() {
-		for _, e := range mr {
-			ch <- e
-		}
-		close(ch)
-	}()
-	return ch
-}
-
-// Less is needed for sorting.
+// Less is needed for sorting. Rows are ordered by their leading entries, largest first;
+// rows which are equal are not less than each other. Unset cells count as zero.
 func (mr MatrixData) Less(l, r int) bool {
-	// TODO: This needs to do a comparison against the matrix rows...
-	for i := 0; i < len(mr[l]); i++ {
-		if mr[l][i] == nil { return mr[r][i] == nil }
-		cmp := mr[l][i].Cmp(mr[r][i])
+	for i := 0; i < len(mr[l]) && i < len(mr[r]); i++ {
+		cmp := compareCells(mr[l][i], mr[r][i])
 		if cmp > 0 {
 			return true
 		}
@@ -48,5 +23,35 @@ func (mr MatrixData) Less(l, r int) bool {
 			return false
 		}
 	}
-	return true
+	return false
+}
+
+// zero stands in for unset cells; it must never be modified.
+var zero = new(Rat)
+
+// cellOrZero is the value of a cell, treating nil as zero.
+func cellOrZero(r *Rat) *Rat {
+	if r == nil {
+		return zero
+	}
+	return r
+}
+
+// copyCell returns a copy of the cell which shares nothing with it, and so
+// is safe to hand to callbacks that may change it. Unset cells give zero.
+func copyCell(r *Rat) *Rat {
+	return new(Rat).Set(cellOrZero(r))
+}
+
+// copyCells copies src into the start of dst cell by cell, so that dst
+// shares no Rat with src. Unset cells come out as zero.
+func copyCells(dst, src MatrixRow) {
+	for j, v := range src {
+		dst[j] = copyCell(v)
+	}
+}
+
+// compareCells compares two cells as Rat.Cmp does, treating nil as zero.
+func compareCells(a, b *Rat) int {
+	return cellOrZero(a).Cmp(cellOrZero(b))
 }
@@ -0,0 +1,225 @@
+/*
+	Gauss-Jordan elimination and the operations built on it.
+*/
+package linear
+
+import (
+	"context"
+)
+
+import . "big"
+
+// gaussJordan reduces a copy of m to reduced row echelon form. It returns the
+// reduced matrix, the pivot column of each nonzero row, and the determinant of
+// the row operations that were applied (the product of the pivots, negated
+// once per row swap).
+func (m Matrix) gaussJordan() (reduced Matrix, pivots []int, det *Rat) {
+	reduced, pivots, det, _ = m.gaussJordanCtx(context.Background(), "eliminate")
+	return
+}
+
+// gaussJordanCtx is gaussJordan, checking ctx before each column is eliminated.
+func (m Matrix) gaussJordanCtx(ctx context.Context, op string) (reduced Matrix, pivots []int, det *Rat, err error) {
+	reduced = m.clone()
+	det = NewRat(1, 1)
+	r := 0
+	for c := 0; c < reduced.cols && r < reduced.rows; c++ {
+		if ctx.Err() != nil {
+			return reduced, pivots, det, &CanceledError{Op: op, Done: c, Total: reduced.cols, Err: ctx.Err()}
+		}
+		p := reduced.pivotRow(r, c)
+		if p < 0 {
+			continue
+		}
+		if p != r {
+			reduced.Swap(p, r)
+			det.Neg(det)
+		}
+		det.Mul(det, reduced.data[r][c])
+		reduced.normalizeRow(r, c)
+		reduced.eliminateColumn(r, c)
+		pivots = append(pivots, c)
+		r++
+	}
+	return
+}
+
+// pivotRow is the first row at or below from with a nonzero entry in column col, or -1.
+func (m Matrix) pivotRow(from, col int) int {
+	for i := from; i < m.rows; i++ {
+		if cellOrZero(m.data[i][col]).Sign() != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeRow divides row r through by its entry in column c.
+func (m Matrix) normalizeRow(r, c int) {
+	pivot := new(Rat).Set(m.data[r][c])
+	for j := range m.data[r] {
+		m.data[r][j] = new(Rat).Quo(cellOrZero(m.data[r][j]), pivot)
+	}
+}
+
+// eliminateColumn clears column c in every row but r, which must already be normalized.
+func (m Matrix) eliminateColumn(r, c int) {
+	for i := range m.data {
+		if i == r || cellOrZero(m.data[i][c]).Sign() == 0 {
+			continue
+		}
+		factor := new(Rat).Set(m.data[i][c])
+		for j := range m.data[i] {
+			m.data[i][j] = new(Rat).Sub(cellOrZero(m.data[i][j]), new(Rat).Mul(factor, m.data[r][j]))
+		}
+	}
+}
+
+// AfterGaussJordanElimination returns the reduced row echelon form of the matrix.
+// The matrix itself is left untouched.
+func (m Matrix) AfterGaussJordanElimination() (Matrix, bool) {
+	reduced, err := m.AfterGaussJordanEliminationCtx(context.Background())
+	return reduced, err == nil
+}
+
+// AfterGaussJordanEliminationCtx is AfterGaussJordanElimination, giving up
+// with a *CanceledError if ctx is done before the elimination finishes.
+func (m Matrix) AfterGaussJordanEliminationCtx(ctx context.Context) (Matrix, error) {
+	if m.IsDegenerate() {
+		return EmptyMatrix(), ErrDegenerate
+	}
+	reduced, _, _, err := m.gaussJordanCtx(ctx, "eliminate")
+	if err != nil {
+		return EmptyMatrix(), err
+	}
+	return reduced, nil
+}
+
+// Rank is the number of linearly independent rows of the matrix.
+func (m Matrix) Rank() (int, bool) {
+	if m.IsDegenerate() {
+		return 0, false
+	}
+	_, pivots, _ := m.gaussJordan()
+	return len(pivots), true
+}
+
+// NullSpace is a basis of the vectors x for which m * x = 0, one for each
+// column of the reduced row echelon form without a pivot. The basis is empty
+// when the columns of the matrix are independent.
+func (m Matrix) NullSpace() ([]Vector, bool) {
+	if m.IsDegenerate() {
+		return nil, false
+	}
+	reduced, pivots, _ := m.gaussJordan()
+	isPivot := make([]bool, m.cols)
+	for _, c := range pivots {
+		isPivot[c] = true
+	}
+	basis := []Vector{}
+	for free := 0; free < m.cols; free++ {
+		if isPivot[free] {
+			continue
+		}
+		v := ZeroVector(m.cols)
+		v[free] = NewRat(1, 1)
+		for r, c := range pivots {
+			v[c] = new(Rat).Neg(reduced.data[r][free])
+		}
+		basis = append(basis, v)
+	}
+	return basis, true
+}
+
+// Det computes the determinant of a square matrix.
+func (m Matrix) Det() (*Rat, bool) {
+	det, err := m.DetCtx(context.Background())
+	return det, err == nil
+}
+
+// DetCtx is Det, giving up with a *CanceledError if ctx is done first.
+func (m Matrix) DetCtx(ctx context.Context) (*Rat, error) {
+	if m.IsDegenerate() {
+		return nil, ErrDegenerate
+	}
+	if m.rows != m.cols {
+		return nil, ErrDimensionMismatch
+	}
+	_, pivots, det, err := m.gaussJordanCtx(ctx, "det")
+	if err != nil {
+		return nil, err
+	}
+	if len(pivots) < m.rows {
+		return NewRat(0, 1), nil
+	}
+	return det, nil
+}
+
+// Inverse of a square matrix, if the matrix is not singular.
+func (m Matrix) Inverse() (Matrix, bool) {
+	inv, err := m.InverseCtx(context.Background())
+	return inv, err == nil
+}
+
+// InverseCtx is Inverse, giving up with a *CanceledError if ctx is done first.
+func (m Matrix) InverseCtx(ctx context.Context) (Matrix, error) {
+	if m.IsDegenerate() {
+		return EmptyMatrix(), ErrDegenerate
+	}
+	if m.rows != m.cols {
+		return EmptyMatrix(), ErrDimensionMismatch
+	}
+	augmented, _ := m.Augment(IdentityMatrix(m.rows))
+	reduced, pivots, _, err := augmented.gaussJordanCtx(ctx, "inverse")
+	if err != nil {
+		return EmptyMatrix(), err
+	}
+	if len(pivots) < m.rows || pivots[m.rows-1] >= m.cols {
+		return EmptyMatrix(), ErrSingular
+	}
+	return reduced.columns(m.cols, reduced.cols), nil
+}
+
+// Solve finds x such that m * x = b. When the system has more than one
+// solution the free variables are set to zero; when it has none, Solve fails.
+func (m Matrix) Solve(b Matrix) (Matrix, bool) {
+	x, err := m.SolveCtx(context.Background(), b)
+	return x, err == nil
+}
+
+// SolveCtx is Solve, giving up with a *CanceledError if ctx is done first.
+func (m Matrix) SolveCtx(ctx context.Context, b Matrix) (Matrix, error) {
+	if m.IsDegenerate() || b.IsDegenerate() {
+		return EmptyMatrix(), ErrDegenerate
+	}
+	if m.rows != b.rows {
+		return EmptyMatrix(), ErrDimensionMismatch
+	}
+	augmented, _ := m.Augment(b)
+	reduced, pivots, _, err := augmented.gaussJordanCtx(ctx, "solve")
+	if err != nil {
+		return EmptyMatrix(), err
+	}
+
+	x := ZeroMatrix(m.cols, b.cols)
+	for r, c := range pivots {
+		if c >= m.cols {
+			// A pivot in the right-hand side means 0 = 1 for some row.
+			return EmptyMatrix(), ErrInconsistent
+		}
+		for j := 0; j < b.cols; j++ {
+			x.data[c][j] = reduced.data[r][m.cols+j]
+		}
+	}
+	return x, nil
+}
+
+// columns copies out columns [from, to) of the matrix.
+func (m Matrix) columns(from, to int) Matrix {
+	result := MakeMatrix(m.rows, to-from)
+	for i, row := range m.data {
+		result.data[i] = make(MatrixRow, to-from)
+		copy(result.data[i], row[from:to])
+	}
+	return result
+}
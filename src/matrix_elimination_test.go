@@ -0,0 +1,210 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestGaussJordanEliminationOfUnitMatrixEqualsUnitMatrix(t *testing.T) {
+	m, success := unitMatrix(4).AfterGaussJordanElimination()
+	if !success || !m.Equals(unitMatrix(4)) {
+		t.Fail()
+	}
+}
+
+func TestGaussJordanEliminationProducesReducedRowEchelonForm(t *testing.T) {
+	m := MakeMatrix(3, 4)
+	m.AddRow(1, 2, -1, -4)
+	m.AddRow(2, 3, -1, -11)
+	m.AddRow(-2, 0, -3, 22)
+
+	expected := MakeMatrix(3, 4)
+	expected.AddRow(1, 0, 0, -8)
+	expected.AddRow(0, 1, 0, 1)
+	expected.AddRow(0, 0, 1, -2)
+
+	actual, _ := m.AfterGaussJordanElimination()
+	if !actual.Equals(expected) {
+		actual.Print("Actual:")
+		t.Fail()
+	}
+}
+
+func TestGaussJordanEliminationLeavesMatrixUntouched(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	m.AfterGaussJordanElimination()
+	if !m.Equals(nonZeroMatrix4x4()) {
+		t.Fail()
+	}
+}
+
+func TestRankOfMatrixWithDuplicateRows(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(1, 2, 3)
+	m.AddRow(2, 4, 6)
+	m.AddRow(0, 1, 1)
+	rank, _ := m.Rank()
+	Fail(t).If(intsAreNotEqual(2, rank))
+}
+
+func TestDetOfUnitMatrixIsOne(t *testing.T) {
+	det, success := unitMatrix(5).Det()
+	if !success {
+		t.Fail()
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), det))
+}
+
+func TestDetOfNonSquareMatrixFails(t *testing.T) {
+	_, success := ZeroMatrix(3, 4).Det()
+	if success {
+		t.Fail()
+	}
+}
+
+func TestDetAccountsForRowSwaps(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(0, 2, 1)
+	m.AddRow(1, 0, 0)
+	m.AddRow(0, 0, 3)
+	det, _ := m.Det()
+	Fail(t).If(rationalsAreNotEqual(NewRat(-6, 1), det))
+}
+
+func TestDetOfSingularMatrixIsZero(t *testing.T) {
+	det, _ := nonZeroMatrix(4, 4).Det()
+	if det.Sign() != 0 {
+		t.Fail()
+	}
+}
+
+func TestMatrixMultipliedByInverseIsUnitMatrix(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	inv, success := m.Inverse()
+	if !success {
+		t.Fatal("nonZeroMatrix4x4 should be invertible")
+	}
+	product, _ := m.Multiply(inv)
+	if !product.Equals(unitMatrix(4)) {
+		product.Print("m * inv(m) = ")
+		t.Fail()
+	}
+}
+
+func TestInverseOfSingularMatrixFails(t *testing.T) {
+	_, success := nonZeroMatrix(3, 3).Inverse()
+	if success {
+		t.Fail()
+	}
+}
+
+func TestSolveFindsUniqueSolution(t *testing.T) {
+	a := MakeMatrix(3, 3)
+	a.AddRow(1, 2, -1)
+	a.AddRow(2, 3, -1)
+	a.AddRow(-2, 0, -3)
+	b := MakeMatrix(3, 1)
+	b.AddRow(-4)
+	b.AddRow(-11)
+	b.AddRow(22)
+
+	expected := MakeMatrix(3, 1)
+	expected.AddRow(-8)
+	expected.AddRow(1)
+	expected.AddRow(-2)
+
+	x, success := a.Solve(b)
+	if !success || !x.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestSolveFailsForInconsistentSystem(t *testing.T) {
+	a := MakeMatrix(2, 2)
+	a.AddRow(1, 1)
+	a.AddRow(2, 2)
+	b := MakeMatrix(2, 1)
+	b.AddRow(1)
+	b.AddRow(3)
+	_, success := a.Solve(b)
+	if success {
+		t.Fail()
+	}
+}
+
+func TestSolveSetsFreeVariablesToZero(t *testing.T) {
+	a := MakeMatrix(1, 2)
+	a.AddRow(1, 1)
+	b := MakeMatrix(1, 1)
+	b.AddRow(5)
+
+	expected := MakeMatrix(2, 1)
+	expected.AddRow(5)
+	expected.AddRow(0)
+
+	x, success := a.Solve(b)
+	if !success || !x.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestNullSpace(t *testing.T) {
+	m := MakeMatrix(2, 4)
+	m.AddRow(1, 2, 0, 1)
+	m.AddRow(2, 4, 1, 4)
+	basis, success := m.NullSpace()
+	if !success {
+		t.Fatal("NullSpace failed")
+	}
+	Fail(t).If(intsAreNotEqual(2, len(basis)))
+	for _, v := range basis {
+		product, _ := m.Multiply(v.ColMatrix())
+		if !product.Equals(ZeroMatrix(2, 1)) {
+			t.Errorf("%v is not in the null space", v)
+		}
+	}
+	if !basis[0].Equals(NewVector(-2, 1, 0, 0)) {
+		t.Errorf("Expected [-2 1 0 0]; Actual %v", basis[0])
+	}
+}
+
+func TestNullSpaceOfInvertibleMatrixIsEmpty(t *testing.T) {
+	basis, success := unitMatrix(3).NullSpace()
+	if !success || len(basis) != 0 {
+		t.Errorf("Expected no vectors; Actual %v", basis)
+	}
+}
+
+func TestEliminationTreatsUnsetCellsAsZero(t *testing.T) {
+	partial := MakeMatrix(3, 3)
+	partial.AddRow(2, 1)
+	partial.AddRow(1)
+	partial.AddRow(0, 0, 3)
+	full := MakeMatrix(3, 3)
+	full.AddRow(2, 1, 0)
+	full.AddRow(1, 0, 0)
+	full.AddRow(0, 0, 3)
+
+	det, success := partial.Det()
+	if !success {
+		t.Fatal("Det failed")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(-3, 1), det))
+
+	inv, success := partial.Inverse()
+	expected, _ := full.Inverse()
+	if !success || !inv.Equals(expected) {
+		t.Errorf("Expected %v; Actual %v", expected, inv)
+	}
+
+	b := MakeMatrix(3, 1)
+	b.AddRow(3)
+	b.AddRow(1)
+	b.AddRow(6)
+	x, success := partial.Solve(b)
+	expected, _ = full.Solve(b)
+	if !success || !x.Equals(expected) {
+		t.Errorf("Expected %v; Actual %v", expected, x)
+	}
+}
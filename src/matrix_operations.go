@@ -12,48 +12,33 @@ import . "big"
 
 // Add the given matrix by another matrix.
 func (m Matrix) Add(addend Matrix) (Matrix, bool) {
-	if m.IsDegenerate() || addend.IsDegenerate() {
-		return EmptyMatrix(), false
-	}
-
-	if !m.hasSameDimension(addend) {
-		return EmptyMatrix(), false
-	}
-
-	result := ZeroMatrix(m.rows, m.cols)
-	for i := range m.data {
-		for j := range m.data[i] {
-			result.data[i][j] = new(Rat).Add(m.data[i][j], addend.data[i][j])
-		}
-	}
-	return result, true
+	return m.zipWith(addend, func(a, b *Rat) *Rat {
+		return new(Rat).Add(a, b)
+	})
 }
 
-// Multiply given matrix by another matrix.
+// Multiply given matrix by another matrix, using up to GOMAXPROCS goroutines.
 func (m Matrix) Multiply(m2 Matrix) (Matrix, bool) {
-	if m.IsDegenerate() || m2.IsDegenerate() {
-		return EmptyMatrix(), false
-	}
-	if !m.hasComplementaryDimension(m2) {
-		return EmptyMatrix(), false
-	}
+	return m.MultiplyWith(m2, 0)
+}
 
-	result := ZeroMatrix(m.rows, m2.cols)
-	for i := 0; i < m.cols; i++ {
-		for j := 0; j < m2.rows; j++ {
-			// TODO: It would be nice not to use a string to communicate the Rational across a channel...
-			ch := make(chan *Rat)
-			go func() {
-				col := m.getCol(j)
-				row := m2.getRow(i)
-				ch <- col.multiply(row).sumAll()
-			}()
-			result.data[i][j] = <- ch
-			// Get col j from m and row i from m2
-			// Multiply the two vectors, and add the values.
+// Transpose returns a new matrix with the rows and columns of m exchanged.
+func (m Matrix) Transpose() Matrix {
+	result := MakeMatrix(m.cols, m.rows)
+	for j := 0; j < m.cols; j++ {
+		result.data[j] = make(MatrixRow, m.rows)
+	}
+	for i, row := range m.data {
+		for j, v := range row {
+			result.data[j][i] = new(Rat).Set(cellOrZero(v))
 		}
 	}
-	return result, true
+	return result
+}
+
+// Augment joins the columns of m2 onto the right of m, as in [m | m2].
+func (m Matrix) Augment(m2 Matrix) (Matrix, bool) {
+	return HStack(m, m2)
 }
 
 // Count leading zeros
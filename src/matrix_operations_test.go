@@ -118,7 +118,7 @@ func TestMatrixCannotBeMultipliedByDegenerateMatrix(t *testing.T) {
 
 func TestMatrixCannotBeMultipliedByMatrixWithWrongDimensions(t *testing.T) {
 	m1 := nonZeroMatrix(5, 4)
-	m2 := nonZeroMatrix(4, 4)
+	m2 := nonZeroMatrix(5, 4)
 	_, success := m1.Multiply(m2)
 	if success {
 		t.Fail()
@@ -263,3 +263,93 @@ func TestUnitMatrixMultipliedByAEqualsA(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMultiplyingNonSquareMatricesProducesCorrectResult(t *testing.T) {
+	a := MakeMatrix(2, 3)
+	a.AddRow(1, 2, 3)
+	a.AddRow(4, 5, 6)
+	b := MakeMatrix(3, 1)
+	b.AddRow(1)
+	b.AddRow(0)
+	b.AddRow(-1)
+
+	expected := MakeMatrix(2, 1)
+	expected.AddRow(-2)
+	expected.AddRow(-2)
+
+	actual, success := a.Multiply(b)
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestMultiplicationIsNotCommutative(t *testing.T) {
+	a := MakeMatrix(2, 2)
+	a.AddRow(1, 1)
+	a.AddRow(0, 1)
+	b := MakeMatrix(2, 2)
+	b.AddRow(1, 0)
+	b.AddRow(1, 1)
+
+	expected := MakeMatrix(2, 2)
+	expected.AddRow(2, 1)
+	expected.AddRow(1, 1)
+
+	actual, _ := a.Multiply(b)
+	if !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestTransposeExchangesRowsAndColumns(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, 2, 3)
+	m.AddRow(4, 5, 6)
+
+	expected := MakeMatrix(3, 2)
+	expected.AddRow(1, 4)
+	expected.AddRow(2, 5)
+	expected.AddRow(3, 6)
+
+	if !m.Transpose().Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestAugmentJoinsColumns(t *testing.T) {
+	a := unitMatrix(2)
+	b := MakeMatrix(2, 1)
+	b.AddRow(7)
+	b.AddRow(8)
+
+	expected := MakeMatrix(2, 3)
+	expected.AddRow(1, 0, 7)
+	expected.AddRow(0, 1, 8)
+
+	actual, success := a.Augment(b)
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestTransposeAndAugmentCopyCells(t *testing.T) {
+	m := nonZeroMatrix(2, 2)
+	transposed := m.Transpose()
+	augmented, _ := m.Augment(unitMatrix(2))
+	for _, v := range transposed.Cells() {
+		v.SetInt64(99)
+	}
+	for _, v := range augmented.Cells() {
+		v.SetInt64(99)
+	}
+	if !m.Equals(nonZeroMatrix(2, 2)) {
+		t.Error("Changing the result changed the original matrix")
+	}
+}
+
+func TestAugmentFailsWithDifferentRowCount(t *testing.T) {
+	_, success := unitMatrix(2).Augment(unitMatrix(3))
+	if success {
+		t.Fail()
+	}
+}
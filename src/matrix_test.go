@@ -313,3 +313,23 @@ func TestSingleCallOnSetCellCreatesADegenerateMatrix(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSetCellAcceptsRational(t *testing.T) {
+	m := ZeroMatrix(2, 2)
+	if !m.SetCell(1, 0, NewRat(3, 4)) {
+		t.Fail()
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(3, 4), m.Cell(1, 0)))
+}
+
+func TestCellOnInvalidAddrShouldReturnNil(t *testing.T) {
+	if ZeroMatrix(2, 2).Cell(2, 0) != nil {
+		t.Fail()
+	}
+}
+
+func TestCellReturnsACopy(t *testing.T) {
+	m := unitMatrix(2)
+	m.Cell(0, 0).SetInt64(5)
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), m.Cell(0, 0)))
+}
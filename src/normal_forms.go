@@ -0,0 +1,246 @@
+/*
+	Hermite and Smith normal forms of integer matrices.
+*/
+package linear
+
+import . "big"
+
+// HermiteForm is the Hermite normal form H = U * m of an integer matrix,
+// where U is unimodular: an integer matrix with determinant 1 or -1, so its
+// inverse is an integer matrix too.
+type HermiteForm struct {
+	H, U Matrix
+}
+
+// SmithForm is the Smith normal form S = U * m * V of an integer matrix,
+// where U and V are unimodular and S is diagonal with nonnegative entries,
+// each dividing the next.
+type SmithForm struct {
+	S, U, V Matrix
+}
+
+// intMatrix is a matrix of integers, which keeps the normal form
+// computations free of the fractions a Matrix would otherwise accumulate.
+type intMatrix [][]*Int
+
+// IsInteger if every entry of the matrix is a whole number. Unset entries count as zero.
+func (m Matrix) IsInteger() bool {
+	if m.IsDegenerate() {
+		return false
+	}
+	for _, row := range m.data {
+		for _, v := range row {
+			if !cellOrZero(v).IsInt() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m Matrix) ints() intMatrix {
+	a := make(intMatrix, m.rows)
+	for i, row := range m.data {
+		a[i] = make([]*Int, m.cols)
+		for j, v := range row {
+			a[i][j] = new(Int).Set(cellOrZero(v).Num())
+		}
+	}
+	return a
+}
+
+func identityInts(n int) intMatrix {
+	a := make(intMatrix, n)
+	for i := range a {
+		a[i] = make([]*Int, n)
+		for j := range a[i] {
+			a[i][j] = new(Int)
+		}
+		a[i][i].SetInt64(1)
+	}
+	return a
+}
+
+func (a intMatrix) matrix(cols int) Matrix {
+	m := MakeMatrix(len(a), cols)
+	for i, row := range a {
+		m.data[i] = make(MatrixRow, cols)
+		for j, v := range row {
+			m.data[i][j] = new(Rat).SetInt(v)
+		}
+	}
+	return m
+}
+
+// addRow adds k times row src to row dst.
+func (a intMatrix) addRow(dst, src int, k *Int) {
+	for j := range a[dst] {
+		a[dst][j].Add(a[dst][j], new(Int).Mul(k, a[src][j]))
+	}
+}
+
+// addCol adds k times column src to column dst.
+func (a intMatrix) addCol(dst, src int, k *Int) {
+	for i := range a {
+		a[i][dst].Add(a[i][dst], new(Int).Mul(k, a[i][src]))
+	}
+}
+
+func (a intMatrix) swapRows(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a intMatrix) swapCols(i, j int) {
+	for _, row := range a {
+		row[i], row[j] = row[j], row[i]
+	}
+}
+
+func (a intMatrix) negateRow(i int) {
+	for _, v := range a[i] {
+		v.Neg(v)
+	}
+}
+
+// smallestBelow is the row at or below from whose entry in column col is
+// nonzero and smallest in absolute value, or -1 if they are all zero.
+func (a intMatrix) smallestBelow(from, col int) int {
+	best := -1
+	for i := from; i < len(a); i++ {
+		if a[i][col].Sign() != 0 && (best < 0 || a[i][col].CmpAbs(a[best][col]) < 0) {
+			best = i
+		}
+	}
+	return best
+}
+
+// HermiteNormalForm reduces an integer matrix by integer row operations to
+// the unique upper triangular form whose pivots are positive and whose
+// entries above each pivot lie in [0, pivot).
+func (m Matrix) HermiteNormalForm() (HermiteForm, bool) {
+	if !m.IsInteger() {
+		return HermiteForm{}, false
+	}
+	a, u := m.ints(), identityInts(m.rows)
+	rowOp := func(dst, src int, k *Int) {
+		a.addRow(dst, src, k)
+		u.addRow(dst, src, k)
+	}
+
+	r := 0
+	for c := 0; c < m.cols && r < m.rows; c++ {
+		// Euclid's algorithm down the column leaves a single nonzero entry, the gcd.
+		for {
+			p := a.smallestBelow(r, c)
+			if p < 0 {
+				break
+			}
+			a.swapRows(p, r)
+			u.swapRows(p, r)
+			done := true
+			for i := r + 1; i < m.rows; i++ {
+				if a[i][c].Sign() == 0 {
+					continue
+				}
+				q := new(Int).Quo(a[i][c], a[r][c])
+				rowOp(i, r, q.Neg(q))
+				if a[i][c].Sign() != 0 {
+					done = false
+				}
+			}
+			if done {
+				break
+			}
+		}
+		if a[r][c].Sign() == 0 {
+			continue
+		}
+		if a[r][c].Sign() < 0 {
+			a.negateRow(r)
+			u.negateRow(r)
+		}
+		for i := 0; i < r; i++ {
+			// Div rounds towards minus infinity for a positive divisor.
+			q := new(Int).Div(a[i][c], a[r][c])
+			rowOp(i, r, q.Neg(q))
+		}
+		r++
+	}
+	return HermiteForm{a.matrix(m.cols), u.matrix(m.rows)}, true
+}
+
+// SmithNormalForm diagonalizes an integer matrix by integer row and column
+// operations. The diagonal entries are the invariant factors of the matrix.
+func (m Matrix) SmithNormalForm() (SmithForm, bool) {
+	if !m.IsInteger() {
+		return SmithForm{}, false
+	}
+	a, u, v := m.ints(), identityInts(m.rows), identityInts(m.cols)
+	rowOp := func(dst, src int, k *Int) {
+		a.addRow(dst, src, k)
+		u.addRow(dst, src, k)
+	}
+	colOp := func(dst, src int, k *Int) {
+		a.addCol(dst, src, k)
+		v.addCol(dst, src, k)
+	}
+
+	for t := 0; t < m.rows && t < m.cols; t++ {
+		for {
+			// Move the smallest nonzero entry left in the submatrix to (t, t).
+			pi, pj := -1, -1
+			for i := t; i < m.rows; i++ {
+				for j := t; j < m.cols; j++ {
+					if a[i][j].Sign() != 0 && (pi < 0 || a[i][j].CmpAbs(a[pi][pj]) < 0) {
+						pi, pj = i, j
+					}
+				}
+			}
+			if pi < 0 {
+				return SmithForm{a.matrix(m.cols), u.matrix(m.rows), v.matrix(m.cols)}, true
+			}
+			a.swapRows(pi, t)
+			u.swapRows(pi, t)
+			a.swapCols(pj, t)
+			v.swapCols(pj, t)
+
+			// Clear the pivot's row and column, starting again from a smaller
+			// pivot whenever a remainder is left behind.
+			clean := true
+			for i := t + 1; i < m.rows; i++ {
+				q := new(Int).Quo(a[i][t], a[t][t])
+				rowOp(i, t, q.Neg(q))
+				clean = clean && a[i][t].Sign() == 0
+			}
+			for j := t + 1; j < m.cols; j++ {
+				q := new(Int).Quo(a[t][j], a[t][t])
+				colOp(j, t, q.Neg(q))
+				clean = clean && a[t][j].Sign() == 0
+			}
+			if !clean {
+				continue
+			}
+
+			// The pivot must divide everything left; if it does not, adding
+			// the offending row brings a smaller remainder into play.
+			offender := -1
+			for i := t + 1; i < m.rows && offender < 0; i++ {
+				for j := t + 1; j < m.cols; j++ {
+					if new(Int).Rem(a[i][j], a[t][t]).Sign() != 0 {
+						offender = i
+						break
+					}
+				}
+			}
+			if offender < 0 {
+				break
+			}
+			rowOp(t, offender, NewInt(1))
+		}
+		if a[t][t].Sign() < 0 {
+			a.negateRow(t)
+			u.negateRow(t)
+		}
+	}
+	return SmithForm{a.matrix(m.cols), u.matrix(m.rows), v.matrix(m.cols)}, true
+}
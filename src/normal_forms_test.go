@@ -0,0 +1,140 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func assertUnimodular(t *testing.T, name string, u Matrix) {
+	det, _ := u.Det()
+	if !det.IsInt() || det.Num().CmpAbs(NewInt(1)) != 0 || !u.IsInteger() {
+		t.Errorf("%s should be unimodular; determinant %v", name, det)
+	}
+}
+
+func TestHermiteNormalForm(t *testing.T) {
+	m := MakeMatrix(3, 4)
+	m.AddRow(2, 3, 6, 2)
+	m.AddRow(5, 6, 1, 6)
+	m.AddRow(8, 3, 1, 1)
+	f, success := m.HermiteNormalForm()
+	if !success {
+		t.Fatal("HermiteNormalForm failed")
+	}
+	expected := MakeMatrix(3, 4)
+	expected.AddRow(1, 0, 50, -11)
+	expected.AddRow(0, 3, 28, -2)
+	expected.AddRow(0, 0, 61, -13)
+	if !f.H.Equals(expected) {
+		f.H.Print("H = ")
+		t.Error("Wrong Hermite normal form")
+	}
+	um, _ := f.U.Multiply(m)
+	if !um.Equals(f.H) {
+		t.Error("U * m should equal H")
+	}
+	assertUnimodular(t, "U", f.U)
+}
+
+func TestHermiteNormalFormOfRankDeficientMatrix(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 4, 6)
+	m.AddRow(1, 2, 4)
+	m.AddRow(3, 6, 10)
+	f, _ := m.HermiteNormalForm()
+	expected := MakeMatrix(3, 3)
+	expected.AddRow(1, 2, 0)
+	expected.AddRow(0, 0, 2)
+	expected.AddRow(0, 0, 0)
+	if !f.H.Equals(expected) {
+		f.H.Print("H = ")
+		t.Error("Wrong Hermite normal form")
+	}
+	um, _ := f.U.Multiply(m)
+	if !um.Equals(f.H) {
+		t.Error("U * m should equal H")
+	}
+	assertUnimodular(t, "U", f.U)
+}
+
+func TestSmithNormalForm(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 4, 4)
+	m.AddRow(-6, 6, 12)
+	m.AddRow(10, -4, -16)
+	f, success := m.SmithNormalForm()
+	if !success {
+		t.Fatal("SmithNormalForm failed")
+	}
+	expected := MakeMatrix(3, 3)
+	expected.AddRow(2, 0, 0)
+	expected.AddRow(0, 6, 0)
+	expected.AddRow(0, 0, 12)
+	if !f.S.Equals(expected) {
+		f.S.Print("S = ")
+		t.Error("Wrong Smith normal form")
+	}
+	um, _ := f.U.Multiply(m)
+	umv, _ := um.Multiply(f.V)
+	if !umv.Equals(f.S) {
+		t.Error("U * m * V should equal S")
+	}
+	assertUnimodular(t, "U", f.U)
+	assertUnimodular(t, "V", f.V)
+}
+
+func TestSmithNormalFormOfRectangularMatrix(t *testing.T) {
+	// The entries have gcd 2 and the 2x2 minors gcd 4, so both factors are 2.
+	m := MakeMatrix(2, 3)
+	m.AddRow(4, 6, 2)
+	m.AddRow(2, 0, 8)
+	f, _ := m.SmithNormalForm()
+	expected := MakeMatrix(2, 3)
+	expected.AddRow(2, 0, 0)
+	expected.AddRow(0, 2, 0)
+	if !f.S.Equals(expected) {
+		f.S.Print("S = ")
+		t.Error("Wrong Smith normal form")
+	}
+	um, _ := f.U.Multiply(m)
+	umv, _ := um.Multiply(f.V)
+	if !umv.Equals(f.S) {
+		t.Error("U * m * V should equal S")
+	}
+}
+
+func TestNormalFormsRejectFractions(t *testing.T) {
+	m := MakeMatrix(1, 2)
+	m.SetCell(0, 0, NewRat(1, 2))
+	m.SetCell(0, 1, 1)
+	if _, success := m.HermiteNormalForm(); success {
+		t.Error("HermiteNormalForm should fail")
+	}
+	if _, success := m.SmithNormalForm(); success {
+		t.Error("SmithNormalForm should fail")
+	}
+}
+
+func TestNormalFormsTreatUnsetCellsAsZero(t *testing.T) {
+	partial := MakeMatrix(2, 3)
+	partial.AddRow(4, 6, 2)
+	partial.AddRow(2)
+	full := MakeMatrix(2, 3)
+	full.AddRow(4, 6, 2)
+	full.AddRow(2, 0, 0)
+	if !partial.IsInteger() {
+		t.Fatal("Unset cells should count as integers")
+	}
+
+	h, success := partial.HermiteNormalForm()
+	expectedH, _ := full.HermiteNormalForm()
+	if !success || !h.H.Equals(expectedH.H) {
+		t.Error("Wrong Hermite normal form")
+	}
+	s, success := partial.SmithNormalForm()
+	expectedS, _ := full.SmithNormalForm()
+	if !success || !s.S.Equals(expectedS.S) {
+		t.Error("Wrong Smith normal form")
+	}
+}
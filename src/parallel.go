@@ -0,0 +1,165 @@
+/*
+	Blocked, parallel matrix multiplication.
+*/
+package linear
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+import . "big"
+
+// blockSize is the edge length of the square tiles the product is divided into.
+// A 32x32 tile of each operand fits comfortably in cache for float64 entries;
+// for rationals it bounds how many big numbers a worker touches at once.
+const blockSize = 32
+
+// block is the half-open range of rows [r0, r1) and columns [c0, c1) of a tile.
+type block struct {
+	r0, r1, c0, c1 int
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// poolSize bounds the requested number of workers by GOMAXPROCS.
+func poolSize(workers int) int {
+	procs := runtime.GOMAXPROCS(0)
+	if workers < 1 || workers > procs {
+		return procs
+	}
+	return workers
+}
+
+// forEachBlock divides a rows x cols grid into tiles and calls fn once for
+// each tile, from at most workers goroutines. It returns when every tile is
+// done, or, if ctx is done first, once the tiles already started have finished;
+// in that case it also reports how many of the tiles were completed.
+func forEachBlock(ctx context.Context, rows, cols, workers int, fn func(b block)) (done, total int, err error) {
+	blocks := make(chan block)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for w := poolSize(workers); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range blocks {
+				fn(b)
+				mu.Lock()
+				done++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	total = ((rows + blockSize - 1) / blockSize) * ((cols + blockSize - 1) / blockSize)
+feed:
+	for r0 := 0; r0 < rows; r0 += blockSize {
+		for c0 := 0; c0 < cols; c0 += blockSize {
+			b := block{r0, minInt(r0+blockSize, rows), c0, minInt(c0+blockSize, cols)}
+			if err = ctx.Err(); err != nil {
+				break feed
+			}
+			select {
+			case blocks <- b:
+			case <-ctx.Done():
+				err = ctx.Err()
+				break feed
+			}
+		}
+	}
+	close(blocks)
+	wg.Wait()
+	return
+}
+
+// MultiplyWith multiplies m by m2 using at most workers goroutines; a count
+// below one, or above GOMAXPROCS, means GOMAXPROCS. Each tile of the result is
+// owned by a single worker, which accumulates it block by block along the shared dimension.
+func (m Matrix) MultiplyWith(m2 Matrix, workers int) (Matrix, bool) {
+	result, err := m.multiplyCtx(context.Background(), m2, workers)
+	return result, err == nil
+}
+
+// MultiplyCtx is Multiply, giving up with a *CanceledError if ctx is done
+// before every block of the product has been computed.
+func (m Matrix) MultiplyCtx(ctx context.Context, m2 Matrix) (Matrix, error) {
+	return m.multiplyCtx(ctx, m2, 0)
+}
+
+func (m Matrix) multiplyCtx(ctx context.Context, m2 Matrix, workers int) (Matrix, error) {
+	if m.IsDegenerate() || m2.IsDegenerate() {
+		return EmptyMatrix(), ErrDegenerate
+	}
+	if !m.canMultiply(m2) {
+		return EmptyMatrix(), ErrDimensionMismatch
+	}
+
+	result := ZeroMatrix(m.rows, m2.cols)
+	done, total, err := forEachBlock(ctx, m.rows, m2.cols, workers, func(b block) {
+		m.multiplyBlock(m2, result, b)
+	})
+	if err != nil {
+		return EmptyMatrix(), &CanceledError{Op: "multiply", Done: done, Total: total, Err: err}
+	}
+	return result, nil
+}
+
+// multiplyBlock adds the product of rows b.r0..b.r1 of m and columns b.c0..b.c1 of m2 into result.
+func (m Matrix) multiplyBlock(m2, result Matrix, b block) {
+	product := new(Rat)
+	for k0 := 0; k0 < m.cols; k0 += blockSize {
+		k1 := minInt(k0+blockSize, m.cols)
+		for i := b.r0; i < b.r1; i++ {
+			row, out := m.data[i], result.data[i]
+			for k := k0; k < k1; k++ {
+				a := cellOrZero(row[k])
+				if a.Sign() == 0 {
+					continue
+				}
+				other := m2.data[k]
+				for j := b.c0; j < b.c1; j++ {
+					out[j].Add(out[j], product.Mul(a, cellOrZero(other[j])))
+				}
+			}
+		}
+	}
+}
+
+// MultiplyWith multiplies m by m2 using at most workers goroutines, as Matrix.MultiplyWith does.
+func (m FloatMatrix) MultiplyWith(m2 FloatMatrix, workers int) (FloatMatrix, bool) {
+	if m.cols != m2.rows {
+		return EmptyFloatMatrix(), false
+	}
+
+	result := MakeFloatMatrix(m.rows, m2.cols)
+	forEachBlock(context.Background(), m.rows, m2.cols, workers, func(b block) {
+		m.multiplyBlock(m2, result, b)
+	})
+	return result, true
+}
+
+func (m FloatMatrix) multiplyBlock(m2, result FloatMatrix, b block) {
+	for k0 := 0; k0 < m.cols; k0 += blockSize {
+		k1 := minInt(k0+blockSize, m.cols)
+		for i := b.r0; i < b.r1; i++ {
+			row, out := m.row(i), result.row(i)
+			for k := k0; k < k1; k++ {
+				a := row[k]
+				if a == 0 {
+					continue
+				}
+				other := m2.row(k)
+				for j := b.c0; j < b.c1; j++ {
+					out[j] += a * other[j]
+				}
+			}
+		}
+	}
+}
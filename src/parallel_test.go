@@ -0,0 +1,140 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func fractionMatrix(rows, cols int) Matrix {
+	m := ZeroMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.data[i][j] = NewRat(int64(i-j), int64(1+(i*j)%7))
+		}
+	}
+	return m
+}
+
+func naiveProduct(m, m2 Matrix) Matrix {
+	result := ZeroMatrix(m.rows, m2.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m2.cols; j++ {
+			result.data[i][j] = m.getRow(i).multiply(m2.getCol(j)).sumAll()
+		}
+	}
+	return result
+}
+
+func floatSequenceMatrix(rows, cols int) FloatMatrix {
+	m := MakeFloatMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, float64(i-j)/float64(1+(i*j)%7))
+		}
+	}
+	return m
+}
+
+func TestBlockedMultiplyMatchesNaiveProductAcrossBlockBoundaries(t *testing.T) {
+	a, b := fractionMatrix(70, 45), fractionMatrix(45, 33)
+	expected := naiveProduct(a, b)
+	for _, workers := range []int{1, 2, 0} {
+		actual, success := a.MultiplyWith(b, workers)
+		if !success || !actual.Equals(expected) {
+			t.Errorf("Wrong product with %d workers", workers)
+		}
+	}
+}
+
+func TestMultiplyDoesNotShareCellsWithOperands(t *testing.T) {
+	a := unitMatrix(2)
+	product, _ := a.Multiply(a)
+	product.data[0][0].SetInt64(5)
+	if !a.Equals(unitMatrix(2)) {
+		t.Fail()
+	}
+}
+
+func TestMultiplyTreatsUnsetCellsAsZero(t *testing.T) {
+	// AddRow leaves the cells after the given values unset.
+	m := MakeMatrix(2, 3)
+	m.AddRow(1, 2)
+	m.AddRow(3)
+	m2 := MakeMatrix(3, 2)
+	m2.AddRow(1, 1)
+	m2.AddRow(2)
+	m2.AddRow(0, 4)
+
+	expected := MakeMatrix(2, 2)
+	expected.AddRow(5, 1)
+	expected.AddRow(3, 3)
+	for _, workers := range []int{1, 4} {
+		actual, success := m.MultiplyWith(m2, workers)
+		if !success || !actual.Equals(expected) {
+			actual.Print("product = ")
+			t.Errorf("Wrong product with %d workers", workers)
+		}
+	}
+
+	f, success := m.Float()
+	if !success || f.At(1, 2) != 0 || f.At(0, 1) != 2 {
+		t.Error("Unset cells should convert to zero")
+	}
+}
+
+func TestFloatMultiplyMatchesRationalMultiply(t *testing.T) {
+	a, b := fractionMatrix(40, 50), fractionMatrix(50, 35)
+	exact, _ := a.Multiply(b)
+	expected, _ := exact.Float()
+
+	af, _ := a.Float()
+	bf, _ := b.Float()
+	for _, workers := range []int{1, 3, 0} {
+		actual, success := af.MultiplyWith(bf, workers)
+		if !success || !actual.EqualsWithin(expected, 1e-9) {
+			t.Errorf("Wrong product with %d workers", workers)
+		}
+	}
+}
+
+func TestFloatMultiplyFailsWithWrongDimensions(t *testing.T) {
+	_, success := MakeFloatMatrix(2, 3).Multiply(MakeFloatMatrix(2, 3))
+	if success {
+		t.Fail()
+	}
+}
+
+func benchmarkRationalMultiply(b *testing.B, n, workers int) {
+	b.StopTimer()
+	m1, m2 := fractionMatrix(n, n), fractionMatrix(n, n)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		m1.MultiplyWith(m2, workers)
+	}
+}
+
+func benchmarkFloatMultiply(b *testing.B, n, workers int) {
+	b.StopTimer()
+	m1, m2 := floatSequenceMatrix(n, n), floatSequenceMatrix(n, n)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		m1.MultiplyWith(m2, workers)
+	}
+}
+
+func BenchmarkMultiplyRational256Sequential(b *testing.B) {
+	benchmarkRationalMultiply(b, 256, 1)
+}
+
+func BenchmarkMultiplyRational256Parallel(b *testing.B) {
+	benchmarkRationalMultiply(b, 256, 0)
+}
+
+func BenchmarkMultiplyFloat256Sequential(b *testing.B) {
+	benchmarkFloatMultiply(b, 256, 1)
+}
+
+func BenchmarkMultiplyFloat256Parallel(b *testing.B) {
+	benchmarkFloatMultiply(b, 256, 0)
+}
@@ -0,0 +1,137 @@
+/*
+	Permutations of the rows and columns of a matrix.
+*/
+package linear
+
+import . "big"
+
+// Permutation is a reordering of n items: entry i is the original index of
+// the item which ends up at position i. It is the form returned by the
+// sorting methods, and applies to a matrix without building the dense
+// permutation matrix.
+type Permutation []int
+
+// IdentityPermutation leaves n items where they are.
+func IdentityPermutation(n int) Permutation {
+	p := make(Permutation, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// IsValid if every index from 0 to len(p)-1 appears exactly once.
+func (p Permutation) IsValid() bool {
+	seen := make([]bool, len(p))
+	for _, v := range p {
+		if v < 0 || v >= len(p) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// Compose returns the permutation which applies p and then q.
+// It fails unless both are valid permutations of the same length.
+func (p Permutation) Compose(q Permutation) (Permutation, bool) {
+	if len(p) != len(q) || !p.IsValid() || !q.IsValid() {
+		return nil, false
+	}
+	result := make(Permutation, len(p))
+	for i, v := range q {
+		result[i] = p[v]
+	}
+	return result, true
+}
+
+// Inverse returns the permutation which undoes p. It fails if p is not valid.
+func (p Permutation) Inverse() (Permutation, bool) {
+	if !p.IsValid() {
+		return nil, false
+	}
+	inv := make(Permutation, len(p))
+	for i, v := range p {
+		inv[v] = i
+	}
+	return inv, true
+}
+
+// Cycles decomposes the permutation into disjoint cycles, leaving out the
+// items which do not move. Each cycle lists positions c[0], c[1], ... such
+// that the item at c[k+1] moves to c[k], and the item at c[0] to the last position.
+// The permutation must be valid.
+func (p Permutation) Cycles() [][]int {
+	var cycles [][]int
+	seen := make([]bool, len(p))
+	for start := range p {
+		if seen[start] || p[start] == start {
+			continue
+		}
+		var cycle []int
+		for i := start; !seen[i]; i = p[i] {
+			seen[i] = true
+			cycle = append(cycle, i)
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// Sign is 1 for a permutation made of an even number of transpositions, and -1 for an odd number.
+// The permutation must be valid.
+func (p Permutation) Sign() int {
+	sign := 1
+	for _, cycle := range p.Cycles() {
+		if len(cycle)%2 == 0 {
+			sign = -sign
+		}
+	}
+	return sign
+}
+
+// IsEven if the permutation is made of an even number of transpositions.
+func (p Permutation) IsEven() bool {
+	return p.Sign() == 1
+}
+
+// ApplyRows returns a matrix whose row i is row p[i] of m. It fails unless p is a valid permutation of the rows.
+// The rows are shared with m rather than copied.
+func (p Permutation) ApplyRows(m Matrix) (Matrix, bool) {
+	if len(p) != m.rows || !p.IsValid() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(m.rows, m.cols)
+	for i, v := range p {
+		result.data[i] = m.data[v]
+	}
+	return result, true
+}
+
+// ApplyCols returns a matrix whose column j is column p[j] of m. It fails unless p is a valid permutation of the columns.
+func (p Permutation) ApplyCols(m Matrix) (Matrix, bool) {
+	if len(p) != m.cols || m.IsDegenerate() || !p.IsValid() {
+		return EmptyMatrix(), false
+	}
+	result := MakeMatrix(m.rows, m.cols)
+	for i, row := range m.data {
+		result.data[i] = make(MatrixRow, m.cols)
+		for j, v := range p {
+			result.data[i][j] = row[v]
+		}
+	}
+	return result, true
+}
+
+// Matrix builds the dense permutation matrix P, so that P * m has the same rows as p.ApplyRows(m).
+// It is the empty matrix if p is not valid.
+func (p Permutation) Matrix() Matrix {
+	if !p.IsValid() {
+		return EmptyMatrix()
+	}
+	m := ZeroMatrix(len(p), len(p))
+	for i, v := range p {
+		m.data[i][v] = NewRat(1, 1)
+	}
+	return m
+}
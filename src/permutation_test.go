@@ -0,0 +1,104 @@
+package linear
+
+import (
+	"testing"
+)
+
+func TestIdentityPermutationIsValidAndEven(t *testing.T) {
+	p := IdentityPermutation(5)
+	if !p.IsValid() || !p.IsEven() || len(p.Cycles()) != 0 {
+		t.Fail()
+	}
+}
+
+func TestPermutationWithRepeatsIsNotValid(t *testing.T) {
+	if (Permutation{0, 2, 2}).IsValid() {
+		t.Fail()
+	}
+}
+
+func TestComposeWithInverseIsIdentity(t *testing.T) {
+	p := Permutation{2, 0, 3, 1}
+	inv, _ := p.Inverse()
+	q, _ := p.Compose(inv)
+	for i, v := range q {
+		Fail(t).If(intsAreNotEqual(i, v))
+	}
+}
+
+func TestComposeAppliesFirstPermutationFirst(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	p, q := Permutation{1, 0, 2, 3}, Permutation{0, 2, 3, 1}
+	pq, _ := p.Compose(q)
+
+	once, _ := p.ApplyRows(m)
+	twice, _ := q.ApplyRows(once)
+	composed, _ := pq.ApplyRows(m)
+	if !twice.Equals(composed) {
+		t.Fail()
+	}
+}
+
+func TestCyclesAndSign(t *testing.T) {
+	p := Permutation{1, 2, 0, 4, 3, 5}
+	cycles := p.Cycles()
+	Fail(t).If(intsAreNotEqual(2, len(cycles)))
+	Fail(t).If(intsAreNotEqual(3, len(cycles[0])))
+	Fail(t).If(intsAreNotEqual(2, len(cycles[1])))
+	Fail(t).If(intsAreNotEqual(-1, p.Sign()))
+}
+
+func TestSignMatchesDeterminantOfPermutationMatrix(t *testing.T) {
+	for _, p := range []Permutation{{0, 1, 2}, {1, 0, 2}, {1, 2, 0}, {2, 1, 0}} {
+		det, _ := p.Matrix().Det()
+		Fail(t).If(intsAreNotEqual(p.Sign(), det.Sign()))
+	}
+}
+
+func TestApplyRowsMatchesPermutationMatrixProduct(t *testing.T) {
+	m := sequenceMatrix(4, 3)
+	p := Permutation{3, 1, 0, 2}
+	expected, _ := p.Matrix().Multiply(m)
+	actual, success := p.ApplyRows(m)
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestApplyColsMatchesPermutationMatrixProduct(t *testing.T) {
+	m := sequenceMatrix(2, 3)
+	p := Permutation{2, 0, 1}
+	expected, _ := m.Multiply(p.Matrix().Transpose())
+	actual, success := p.ApplyCols(m)
+	if !success || !actual.Equals(expected) {
+		t.Fail()
+	}
+}
+
+func TestApplyWithWrongLengthFails(t *testing.T) {
+	if _, success := IdentityPermutation(3).ApplyRows(unitMatrix(4)); success {
+		t.Fail()
+	}
+}
+
+func TestInvalidPermutationsFail(t *testing.T) {
+	p := Permutation{0, 5}
+	if _, success := p.Inverse(); success {
+		t.Error("Inverse should fail")
+	}
+	if _, success := p.Compose(IdentityPermutation(2)); success {
+		t.Error("Compose should fail")
+	}
+	if _, success := IdentityPermutation(2).Compose(p); success {
+		t.Error("Compose should fail")
+	}
+	if _, success := p.ApplyRows(unitMatrix(2)); success {
+		t.Error("ApplyRows should fail")
+	}
+	if _, success := p.ApplyCols(unitMatrix(2)); success {
+		t.Error("ApplyCols should fail")
+	}
+	if !p.Matrix().IsEmpty() {
+		t.Error("Matrix should be empty")
+	}
+}
@@ -0,0 +1,371 @@
+/*
+	Polynomials with rational coefficients.
+*/
+package linear
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+import . "big"
+
+// Polynomial is a list of rational coefficients, constant term first, so
+// that p[i] is the coefficient of x^i. The zero polynomial has no coefficients.
+type Polynomial []*Rat
+
+// NewPolynomial creates a polynomial with integer coefficients, constant term first.
+func NewPolynomial(coeffs ...int64) Polynomial {
+	p := make(Polynomial, len(coeffs))
+	for i, c := range coeffs {
+		p[i] = NewRat(c, 1)
+	}
+	return p.trim()
+}
+
+// trim drops zero leading coefficients.
+func (p Polynomial) trim() Polynomial {
+	n := len(p)
+	for n > 0 && cellOrZero(p[n-1]).Sign() == 0 {
+		n--
+	}
+	return p[:n]
+}
+
+// Degree of the polynomial; the zero polynomial has degree -1.
+func (p Polynomial) Degree() int {
+	return len(p.trim()) - 1
+}
+
+// Coefficient of x^i.
+func (p Polynomial) Coefficient(i int) *Rat {
+	if i < 0 || i >= len(p) {
+		return new(Rat)
+	}
+	return new(Rat).Set(cellOrZero(p[i]))
+}
+
+// Leading is the coefficient of the highest power of x.
+func (p Polynomial) Leading() *Rat {
+	return p.Coefficient(p.Degree())
+}
+
+// Equals if the polynomials have the same coefficients.
+func (p Polynomial) Equals(q Polynomial) bool {
+	return Vector(p.trim()).Equals(Vector(q.trim()))
+}
+
+// Eval computes p(x) by Horner's rule.
+func (p Polynomial) Eval(x *Rat) *Rat {
+	result := new(Rat)
+	for i := p.Degree(); i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, cellOrZero(p[i]))
+	}
+	return result
+}
+
+// EvalMatrix computes p(m) for a square matrix by Horner's rule, with the
+// constant term standing for a multiple of the identity.
+func (p Polynomial) EvalMatrix(m Matrix) (Matrix, bool) {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return EmptyMatrix(), false
+	}
+	result := ZeroMatrix(m.rows, m.cols)
+	for i := p.Degree(); i >= 0; i-- {
+		result, _ = result.Multiply(m)
+		constant, _ := IdentityMatrix(m.rows).Scale(cellOrZero(p[i]))
+		result, _ = result.Add(constant)
+	}
+	return result, true
+}
+
+// Add the given polynomial to another polynomial.
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	result := make(Polynomial, n)
+	for i := range result {
+		result[i] = new(Rat).Add(p.Coefficient(i), q.Coefficient(i))
+	}
+	return result.trim()
+}
+
+// Sub subtracts the given polynomial from another polynomial.
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return p.Add(q.Scale(NewRat(-1, 1)))
+}
+
+// Scale multiplies every coefficient by k.
+func (p Polynomial) Scale(k *Rat) Polynomial {
+	return Polynomial(Vector(p).Scale(k)).trim()
+}
+
+// Mul multiplies two polynomials.
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	p, q = p.trim(), q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return Polynomial{}
+	}
+	result := make(Polynomial, len(p)+len(q)-1)
+	for k := range result {
+		result[k] = new(Rat)
+	}
+	for i, a := range p {
+		for j, b := range q {
+			result[i+j].Add(result[i+j], new(Rat).Mul(cellOrZero(a), cellOrZero(b)))
+		}
+	}
+	return result.trim()
+}
+
+// DivMod divides p by d, giving the quotient and a remainder of lower degree than d.
+// It fails if d is the zero polynomial.
+func (p Polynomial) DivMod(d Polynomial) (q, r Polynomial, ok bool) {
+	d = d.trim()
+	if len(d) == 0 {
+		return nil, nil, false
+	}
+	r = p.Scale(NewRat(1, 1))
+	q = Polynomial{}
+	lead := d.Leading()
+	for r.Degree() >= d.Degree() {
+		shift := r.Degree() - d.Degree()
+		term := make(Polynomial, shift+1)
+		for i := range term {
+			term[i] = new(Rat)
+		}
+		term[shift] = new(Rat).Quo(r.Leading(), lead)
+		q = q.Add(term)
+		r = r.Sub(d.Mul(term))
+	}
+	return q, r, true
+}
+
+// Monic scales the polynomial so its leading coefficient is one.
+func (p Polynomial) Monic() Polynomial {
+	if p.Degree() < 0 {
+		return Polynomial{}
+	}
+	return p.Scale(new(Rat).Quo(NewRat(1, 1), p.Leading()))
+}
+
+// GCD is the monic greatest common divisor of two polynomials.
+func (p Polynomial) GCD(q Polynomial) Polynomial {
+	a, b := p.trim(), q.trim()
+	for len(b) > 0 {
+		_, r, _ := a.DivMod(b)
+		a, b = b, r
+	}
+	return a.Monic()
+}
+
+// Derivative of the polynomial.
+func (p Polynomial) Derivative() Polynomial {
+	if p.Degree() < 1 {
+		return Polynomial{}
+	}
+	result := make(Polynomial, p.Degree())
+	for i := range result {
+		result[i] = new(Rat).Mul(p.Coefficient(i+1), NewRat(int64(i+1), 1))
+	}
+	return result.trim()
+}
+
+func (p Polynomial) String() string {
+	return p.format("x")
+}
+
+// format writes the polynomial out highest power first, in terms of the given variable.
+func (p Polynomial) format(variable string) string {
+	if p.Degree() < 0 {
+		return "0"
+	}
+	var terms []string
+	for i := p.Degree(); i >= 0; i-- {
+		c := p.Coefficient(i)
+		if c.Sign() == 0 {
+			continue
+		}
+		sign := "+"
+		if c.Sign() < 0 {
+			sign = "-"
+			c.Neg(c)
+		}
+		coeff := c.RatString()
+		if coeff == "1" && i > 0 {
+			coeff = ""
+		}
+		var term string
+		switch i {
+		case 0:
+			term = coeff
+		case 1:
+			term = coeff + variable
+		default:
+			term = fmt.Sprintf("%s%s^%d", coeff, variable, i)
+		}
+		terms = append(terms, sign, term)
+	}
+	if terms[0] == "+" {
+		terms = terms[1:]
+	} else {
+		terms[1] = "-" + terms[1]
+		terms = terms[1:]
+	}
+	return strings.Join(terms, " ")
+}
+
+// RationalRoot is a root of a polynomial and how many times it is repeated.
+type RationalRoot struct {
+	Value        *Rat
+	Multiplicity int
+}
+
+// RationalRoots finds every rational root of the polynomial, smallest first.
+// The search works on the square-free part, rescaled to a monic polynomial
+// with integer coefficients so that every rational root becomes an integer.
+// Sturm sequences then bisect the Cauchy bound down to single integers, so
+// the time taken grows with the logarithm of the coefficients rather than
+// with the number of their divisors.
+func (p Polynomial) RationalRoots() []RationalRoot {
+	var roots []RationalRoot
+	p = p.trim()
+	if len(p) == 0 {
+		return nil
+	}
+
+	zeros := 0
+	for cellOrZero(p[zeros]).Sign() == 0 {
+		zeros++
+	}
+	if zeros > 0 {
+		roots = append(roots, RationalRoot{new(Rat), zeros})
+		p = p[zeros:]
+	}
+	if p.Degree() < 1 {
+		return roots
+	}
+
+	free := p
+	if g := p.GCD(p.Derivative()); g.Degree() > 0 {
+		free, _, _ = p.DivMod(g)
+	}
+	coeffs := free.integerCoefficients()
+	n := len(coeffs) - 1
+	lead := coeffs[n]
+
+	// Substituting x = y/lead and multiplying through by lead^(n-1) gives a
+	// monic polynomial whose rational roots are all integers.
+	monic := make(Polynomial, n+1)
+	power := NewInt(1)
+	for i := n - 1; i >= 0; i-- {
+		monic[i] = new(Rat).SetInt(new(Int).Mul(coeffs[i], power))
+		power.Mul(power, lead)
+	}
+	monic[n] = NewRat(1, 1)
+
+	for _, y := range monic.integerRoots() {
+		c := new(Rat).SetFrac(y, lead)
+		multiplicity := 0
+		linear := Polynomial{new(Rat).Neg(c), NewRat(1, 1)}
+		for p.Degree() > 0 && p.Eval(c).Sign() == 0 {
+			p, _, _ = p.DivMod(linear)
+			multiplicity++
+		}
+		roots = append(roots, RationalRoot{c, multiplicity})
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Value.Cmp(roots[j].Value) < 0 })
+	return roots
+}
+
+// integerCoefficients scales the polynomial by the least common multiple of its denominators.
+func (p Polynomial) integerCoefficients() []*Int {
+	lcm := NewInt(1)
+	for _, c := range p {
+		d := cellOrZero(c).Denom()
+		g := new(Int).GCD(nil, nil, lcm, d)
+		lcm.Mul(lcm, new(Int).Quo(d, g))
+	}
+	coeffs := make([]*Int, len(p))
+	for i, c := range p {
+		scaled := new(Rat).Mul(cellOrZero(c), new(Rat).SetInt(lcm))
+		coeffs[i] = new(Int).Set(scaled.Num())
+	}
+	return coeffs
+}
+
+// integerRoots finds the integer roots of a square-free monic polynomial
+// with integer coefficients, smallest first.
+func (p Polynomial) integerRoots() []*Int {
+	sturm := p.sturmSequence()
+
+	// Every root lies within the Cauchy bound 1 + max |c_i|.
+	bound := NewInt(0)
+	for _, c := range p[:len(p)-1] {
+		if a := new(Int).Abs(cellOrZero(c).Num()); a.Cmp(bound) > 0 {
+			bound = a
+		}
+	}
+	bound.Add(bound, NewInt(1))
+
+	var roots []*Int
+	var search func(lo, hi *Int, count int)
+	// search looks for roots in (lo, hi], which holds count distinct real roots.
+	search = func(lo, hi *Int, count int) {
+		if count == 0 {
+			return
+		}
+		width := new(Int).Sub(hi, lo)
+		if width.Cmp(NewInt(1)) <= 0 {
+			if p.Eval(new(Rat).SetInt(hi)).Sign() == 0 {
+				roots = append(roots, hi)
+			}
+			return
+		}
+		mid := new(Int).Add(lo, width.Rsh(width, 1))
+		left := signChanges(sturm, lo) - signChanges(sturm, mid)
+		search(lo, mid, left)
+		search(mid, hi, count-left)
+	}
+	lo := new(Int).Neg(bound)
+	lo.Sub(lo, NewInt(1))
+	search(lo, bound, signChanges(sturm, lo)-signChanges(sturm, bound))
+	return roots
+}
+
+// sturmSequence returns p, p' and the negated remainders of Euclid's algorithm on them.
+func (p Polynomial) sturmSequence() []Polynomial {
+	sequence := []Polynomial{p, p.Derivative()}
+	for {
+		a, b := sequence[len(sequence)-2], sequence[len(sequence)-1]
+		if b.Degree() < 1 {
+			return sequence
+		}
+		_, r, _ := a.DivMod(b)
+		if r.Degree() < 0 {
+			return sequence
+		}
+		sequence = append(sequence, r.Scale(NewRat(-1, 1)))
+	}
+}
+
+// signChanges counts the sign changes along a Sturm sequence evaluated at x, skipping zeros.
+func signChanges(sequence []Polynomial, x *Int) int {
+	at := new(Rat).SetInt(x)
+	changes, last := 0, 0
+	for _, q := range sequence {
+		s := q.Eval(at).Sign()
+		if s == 0 {
+			continue
+		}
+		if last != 0 && s != last {
+			changes++
+		}
+		last = s
+	}
+	return changes
+}
@@ -0,0 +1,152 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestPolynomialArithmetic(t *testing.T) {
+	p := NewPolynomial(-1, 0, 1) // x^2 - 1
+	q := NewPolynomial(1, 1)     // x + 1
+	if !p.Mul(q).Equals(NewPolynomial(-1, -1, 1, 1)) {
+		t.Errorf("Wrong product %v", p.Mul(q))
+	}
+	quo, rem, ok := p.DivMod(q)
+	if !ok || !quo.Equals(NewPolynomial(-1, 1)) || rem.Degree() != -1 {
+		t.Errorf("Expected x - 1 remainder 0; Actual %v remainder %v", quo, rem)
+	}
+	if _, _, ok := p.DivMod(Polynomial{}); ok {
+		t.Error("Division by the zero polynomial should fail")
+	}
+	if !p.Sub(p).Equals(Polynomial{}) {
+		t.Error("p - p should be zero")
+	}
+	if !p.GCD(NewPolynomial(2, -1, -1)).Equals(NewPolynomial(-1, 1)) {
+		t.Errorf("Wrong gcd %v", p.GCD(NewPolynomial(2, -1, -1)))
+	}
+	if !p.Derivative().Equals(NewPolynomial(0, 2)) {
+		t.Errorf("Wrong derivative %v", p.Derivative())
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(8, 1), p.Eval(NewRat(3, 1))))
+}
+
+func TestPolynomialString(t *testing.T) {
+	p := Polynomial{NewRat(1, 2), NewRat(-1, 1), new(Rat), NewRat(-3, 1)}
+	if s := p.String(); s != "-3x^3 - x + 1/2" {
+		t.Errorf("Actual %q", s)
+	}
+	if s := (Polynomial{}).String(); s != "0" {
+		t.Errorf("Actual %q", s)
+	}
+}
+
+func TestRationalRootsWithMultiplicity(t *testing.T) {
+	// (2x - 1)(x + 3)^2 x
+	p := NewPolynomial(0, -9, 12, 11, 2)
+	roots := p.RationalRoots()
+	expected := []RationalRoot{{NewRat(-3, 1), 2}, {new(Rat), 1}, {NewRat(1, 2), 1}}
+	if len(roots) != len(expected) {
+		t.Fatalf("Expected %v; Actual %v", expected, roots)
+	}
+	for i, r := range roots {
+		if r.Value.Cmp(expected[i].Value) != 0 || r.Multiplicity != expected[i].Multiplicity {
+			t.Errorf("Expected %v; Actual %v", expected[i], r)
+		}
+	}
+}
+
+func TestRationalRootsIgnoresIrrationalRoots(t *testing.T) {
+	// (x^2 - 2)(3x + 2) with fractional coefficients scaled by 1/6
+	p := NewPolynomial(-4, -6, 2, 3).Scale(NewRat(1, 6))
+	roots := p.RationalRoots()
+	if len(roots) != 1 || roots[0].Value.Cmp(NewRat(-2, 3)) != 0 {
+		t.Errorf("Expected only -2/3; Actual %v", roots)
+	}
+}
+
+func TestRationalRootsWithLargeCoefficients(t *testing.T) {
+	// (1000003x - 7)(x - 999983)^2 (x^2 + 1)
+	p := NewPolynomial(-7, 1000003).Mul(NewPolynomial(-999983, 1)).Mul(NewPolynomial(-999983, 1)).Mul(NewPolynomial(1, 0, 1))
+	roots := p.RationalRoots()
+	expected := []RationalRoot{{NewRat(7, 1000003), 1}, {NewRat(999983, 1), 2}}
+	if len(roots) != len(expected) {
+		t.Fatalf("Expected %v; Actual %v", expected, roots)
+	}
+	for i, r := range roots {
+		if r.Value.Cmp(expected[i].Value) != 0 || r.Multiplicity != expected[i].Multiplicity {
+			t.Errorf("Expected %v; Actual %v", expected[i], r)
+		}
+	}
+}
+
+func TestCharPoly(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 1, 0)
+	m.AddRow(0, 2, 0)
+	m.AddRow(0, 0, 3)
+	p, success := m.CharPoly()
+	if !success {
+		t.Fatal("CharPoly failed")
+	}
+	// (x - 2)^2 (x - 3)
+	if !p.Equals(NewPolynomial(-12, 16, -7, 1)) {
+		t.Errorf("Wrong characteristic polynomial %v", p)
+	}
+	det, _ := m.Det()
+	Fail(t).If(rationalsAreNotEqual(new(Rat).Neg(det), p.Coefficient(0)))
+}
+
+func TestCayleyHamilton(t *testing.T) {
+	m := fractionMatrix(4, 4)
+	p, _ := m.CharPoly()
+	result, success := p.EvalMatrix(m)
+	if !success || !result.Equals(ZeroMatrix(4, 4)) {
+		t.Error("Matrix should satisfy its characteristic polynomial")
+	}
+}
+
+func TestMinimalPoly(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(2, 0, 0)
+	m.AddRow(0, 2, 0)
+	m.AddRow(0, 0, 3)
+	p, success := m.MinimalPoly()
+	if !success || !p.Equals(NewPolynomial(6, -5, 1)) {
+		t.Errorf("Expected (x - 2)(x - 3); Actual %v", p)
+	}
+
+	jordan := MakeMatrix(3, 3)
+	jordan.AddRow(2, 1, 0)
+	jordan.AddRow(0, 2, 0)
+	jordan.AddRow(0, 0, 3)
+	p, _ = jordan.MinimalPoly()
+	charPoly, _ := jordan.CharPoly()
+	if !p.Equals(charPoly) {
+		t.Errorf("Expected %v; Actual %v", charPoly, p)
+	}
+}
+
+func TestTraceAndCharPolyTreatUnsetCellsAsZero(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(3, 1)
+	m.AddRow(2)
+	trace, success := m.Trace()
+	if !success {
+		t.Fatal("Trace failed")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(3, 1), trace))
+	p, _ := m.CharPoly()
+	if !p.Equals(NewPolynomial(-2, -3, 1)) {
+		t.Errorf("Expected x^2 - 3x - 2; Actual %v", p)
+	}
+}
+
+func TestCharPolyFailsForNonSquareMatrix(t *testing.T) {
+	if _, success := nonZeroMatrix(2, 3).CharPoly(); success {
+		t.Error("CharPoly should fail")
+	}
+	if _, success := nonZeroMatrix(2, 3).MinimalPoly(); success {
+		t.Error("MinimalPoly should fail")
+	}
+}
@@ -0,0 +1,124 @@
+/*
+	Integer powers and the exponential of square matrices.
+*/
+package linear
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+import . "big"
+
+// Pow raises a square matrix to an integer power by repeated squaring,
+// taking O(log n) multiplications. Negative powers are powers of the
+// inverse, so they fail for singular matrices; m^0 is the identity.
+func (m Matrix) Pow(n int) (Matrix, bool) {
+	if m.IsDegenerate() || m.rows != m.cols {
+		return EmptyMatrix(), false
+	}
+	base := m
+	if n < 0 {
+		inv, ok := m.Inverse()
+		if !ok {
+			return EmptyMatrix(), false
+		}
+		base, n = inv, -n
+	}
+	result := IdentityMatrix(m.rows)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result, _ = result.Multiply(base)
+		}
+		if n > 1 {
+			base, _ = base.Multiply(base)
+		}
+	}
+	return result, true
+}
+
+// ExpTerm is the term e^Exponent * Coefficient of an exponential.
+type ExpTerm struct {
+	Exponent    *Rat
+	Coefficient Matrix
+}
+
+// SymbolicExp is the exponential of a rational matrix written exactly, as a
+// sum of rational matrices each multiplied by e raised to an eigenvalue.
+type SymbolicExp []ExpTerm
+
+// Exp computes the exponential of a square matrix with rational
+// eigenvalues, which includes every nilpotent matrix and every matrix
+// diagonalizable over the rationals. From the Jordan form m = P J P⁻¹, each
+// eigenvalue λ contributes e^λ P E P⁻¹, where E is the sum of N^k/k! for the
+// nilpotent part N of the Jordan blocks of λ, and zero elsewhere.
+func (m Matrix) Exp() (SymbolicExp, bool) {
+	f, ok := m.JordanForm()
+	if !ok {
+		return nil, false
+	}
+	pInv, _ := f.P.Inverse()
+	n := m.rows
+
+	var result SymbolicExp
+	for i := 0; i < n; i++ {
+		lambda := f.J.data[i][i]
+		if i > 0 && lambda.Cmp(f.J.data[i-1][i-1]) == 0 {
+			continue
+		}
+		// mask projects onto the generalized eigenspace of lambda.
+		mask := ZeroMatrix(n, n)
+		for k := 0; k < n; k++ {
+			if f.J.data[k][k].Cmp(lambda) == 0 {
+				mask.data[k][k].SetInt64(1)
+			}
+		}
+		shift, _ := IdentityMatrix(n).Scale(lambda)
+		nilpotent, _ := f.J.Sub(shift)
+		nilpotent, _ = mask.Multiply(nilpotent)
+
+		sum, term := mask, mask
+		for k := 1; k < n; k++ {
+			term, _ = term.Multiply(nilpotent)
+			term, _ = term.Scale(NewRat(1, int64(k)))
+			sum, _ = sum.Add(term)
+		}
+		pe, _ := f.P.Multiply(sum)
+		coefficient, _ := pe.Multiply(pInv)
+		result = append(result, ExpTerm{new(Rat).Set(lambda), coefficient})
+	}
+	return result, true
+}
+
+// Float evaluates the exponential in floating point.
+func (s SymbolicExp) Float() FloatMatrix {
+	if len(s) == 0 {
+		return EmptyFloatMatrix()
+	}
+	result := MakeFloatMatrix(s[0].Coefficient.rows, s[0].Coefficient.cols)
+	for _, term := range s {
+		exponent, _ := term.Exponent.Float64()
+		c, _ := term.Coefficient.Float()
+		for i, v := range c.data {
+			result.data[i] += math.Exp(exponent) * v
+		}
+	}
+	return result
+}
+
+func (s SymbolicExp) String() string {
+	terms := make([]string, len(s))
+	for i, term := range s {
+		rows := make([]string, term.Coefficient.rows)
+		for r := range rows {
+			cells := make([]string, term.Coefficient.cols)
+			for c := range cells {
+				cells[c] = term.Coefficient.data[r][c].RatString()
+			}
+			rows[r] = strings.Join(cells, ", ")
+		}
+		terms[i] = fmt.Sprintf("e^%s [%s]", term.Exponent.RatString(), strings.Join(rows, "; "))
+	}
+	return strings.Join(terms, " + ")
+}
@@ -0,0 +1,98 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func fibonacciMatrix() Matrix {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 1)
+	m.AddRow(1, 0)
+	return m
+}
+
+func TestPowMatchesRepeatedMultiplication(t *testing.T) {
+	m := fractionMatrix(3, 3)
+	expected := IdentityMatrix(3)
+	for n := 0; n <= 9; n++ {
+		actual, success := m.Pow(n)
+		if !success || !actual.Equals(expected) {
+			t.Errorf("Wrong power %d", n)
+		}
+		expected, _ = expected.Multiply(m)
+	}
+}
+
+func TestPowOfFibonacciMatrix(t *testing.T) {
+	m, _ := fibonacciMatrix().Pow(90)
+	expected, _ := new(Int).SetString("2880067194370816120", 10)
+	if m.Cell(0, 1).Num().Cmp(expected) != 0 {
+		t.Errorf("Expected F(90) = %v; Actual %v", expected, m.Cell(0, 1))
+	}
+}
+
+func TestNegativePowIsPowerOfInverse(t *testing.T) {
+	m := fibonacciMatrix()
+	negative, success := m.Pow(-3)
+	if !success {
+		t.Fatal("Pow failed")
+	}
+	positive, _ := m.Pow(3)
+	product, _ := negative.Multiply(positive)
+	if !product.Equals(IdentityMatrix(2)) {
+		t.Error("m^-3 * m^3 should be the identity")
+	}
+
+	singular := MakeMatrix(2, 2)
+	singular.AddRow(1, 2)
+	singular.AddRow(2, 4)
+	if _, success := singular.Pow(-1); success {
+		t.Error("Singular matrix has no negative powers")
+	}
+}
+
+func TestExpOfDiagonalizableMatrix(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 1)
+	m.AddRow(0, 2)
+	e, success := m.Exp()
+	if !success {
+		t.Fatal("Exp failed")
+	}
+	// exp(m) = e [1 -1; 0 0] + e^2 [0 1; 0 1]
+	Fail(t).If(intsAreNotEqual(2, len(e)))
+	if s := e.String(); s != "e^1 [1, -1; 0, 0] + e^2 [0, 1; 0, 1]" {
+		t.Errorf("Actual %s", s)
+	}
+}
+
+func TestExpOfNilpotentMatrix(t *testing.T) {
+	m := MakeMatrix(3, 3)
+	m.AddRow(0, 1, 0)
+	m.AddRow(0, 0, 2)
+	m.AddRow(0, 0, 0)
+	e, success := m.Exp()
+	if !success || len(e) != 1 {
+		t.Fatal("Expected a single term")
+	}
+	expected := MakeMatrix(3, 3)
+	expected.AddRow(1, 1, 1)
+	expected.AddRow(0, 1, 2)
+	expected.AddRow(0, 0, 1)
+	Fail(t).If(rationalsAreNotEqual(new(Rat), e[0].Exponent))
+	if !e[0].Coefficient.Equals(expected) {
+		e[0].Coefficient.Print("exp = ")
+		t.Error("Expected I + N + N^2/2")
+	}
+}
+
+func TestExpFailsForIrrationalEigenvalues(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(0, 2)
+	m.AddRow(1, 0)
+	if _, success := m.Exp(); success {
+		t.Error("Exp should fail")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+	Linear recurrences with constant rational coefficients.
+*/
+package linear
+
+import (
+	"fmt"
+	"strings"
+)
+
+import . "big"
+
+// Recurrence is the sequence defined by its first k terms and
+//
+//	a(n) = c(1) a(n-1) + c(2) a(n-2) + ... + c(k) a(n-k)
+type Recurrence struct {
+	// Coefficients are c(1) to c(k).
+	Coefficients []*Rat
+	// Initial are the terms a(0) to a(k-1).
+	Initial []*Rat
+}
+
+// NewRecurrence creates a recurrence of order k from k coefficients and k initial terms.
+func NewRecurrence(coeffs, initial []*Rat) (Recurrence, bool) {
+	if len(coeffs) == 0 || len(coeffs) != len(initial) {
+		return Recurrence{}, false
+	}
+	r := Recurrence{make([]*Rat, len(coeffs)), make([]*Rat, len(initial))}
+	for i := range coeffs {
+		r.Coefficients[i] = new(Rat).Set(coeffs[i])
+		r.Initial[i] = new(Rat).Set(initial[i])
+	}
+	return r, true
+}
+
+// CharPoly is the characteristic polynomial x^k - c(1) x^(k-1) - ... - c(k).
+func (r Recurrence) CharPoly() Polynomial {
+	k := len(r.Coefficients)
+	p := make(Polynomial, k+1)
+	for i, c := range r.Coefficients {
+		p[k-1-i] = new(Rat).Neg(c)
+	}
+	p[k] = NewRat(1, 1)
+	return p
+}
+
+// Companion is the companion matrix of the characteristic polynomial. Its
+// transpose steps the recurrence: it takes (a(n), ..., a(n+k-1)) to
+// (a(n+1), ..., a(n+k)).
+func (r Recurrence) Companion() Matrix {
+	c, _ := CompanionMatrix(r.CharPoly())
+	return c
+}
+
+// Term computes a(n) exactly by raising the step matrix to the nth power,
+// in O(log n) matrix multiplications. Negative n runs the recurrence
+// backwards, which fails if c(k) is zero.
+func (r Recurrence) Term(n int) (*Rat, bool) {
+	step, ok := r.Companion().Transpose().Pow(n)
+	if !ok {
+		return nil, false
+	}
+	state, _ := step.Multiply(Vector(r.Initial).ColMatrix())
+	return state.Cell(0, 0), true
+}
+
+// ClosedFormTerm is Poly(n) * Base^n.
+type ClosedFormTerm struct {
+	Base *Rat
+	Poly Polynomial
+}
+
+// ClosedForm expresses the terms a(n) of a recurrence, for n >= From, as a
+// sum of polynomials in n times powers of the roots of its characteristic
+// polynomial. From is the multiplicity of zero as a root, which only
+// affects the first few terms.
+type ClosedForm struct {
+	Terms []ClosedFormTerm
+	From  int
+}
+
+// ClosedForm solves the recurrence when its characteristic polynomial
+// factors into rational linear factors. A root λ of multiplicity m
+// contributes a polynomial of degree below m times λ^n; the coefficients
+// are fitted exactly to the terms a(From) onwards.
+func (r Recurrence) ClosedForm() (ClosedForm, bool) {
+	roots := r.CharPoly().RationalRoots()
+	from, unknowns := 0, 0
+	for _, root := range roots {
+		if root.Value.Sign() == 0 {
+			from = root.Multiplicity
+		} else {
+			unknowns += root.Multiplicity
+		}
+	}
+	if from+unknowns < len(r.Coefficients) {
+		return ClosedForm{}, false
+	}
+	if unknowns == 0 {
+		return ClosedForm{From: from}, true
+	}
+
+	// Row i is the equation for a(from+i); column j the coefficient of n^e λ^n.
+	system := ZeroMatrix(unknowns, unknowns)
+	values := ZeroMatrix(unknowns, 1)
+	for i := 0; i < unknowns; i++ {
+		n := from + i
+		values.data[i][0], _ = r.Term(n)
+		j := 0
+		for _, root := range roots {
+			if root.Value.Sign() == 0 {
+				continue
+			}
+			power := ratPow(root.Value, n)
+			for e := 0; e < root.Multiplicity; e++ {
+				system.data[i][j] = new(Rat).Mul(power, ratPow(NewRat(int64(n), 1), e))
+				j++
+			}
+		}
+	}
+	coeffs, ok := system.Solve(values)
+	if !ok {
+		return ClosedForm{}, false
+	}
+
+	f := ClosedForm{From: from}
+	j := 0
+	for _, root := range roots {
+		if root.Value.Sign() == 0 {
+			continue
+		}
+		poly := make(Polynomial, root.Multiplicity)
+		for e := range poly {
+			poly[e] = coeffs.data[j][0]
+			j++
+		}
+		if poly.Degree() >= 0 {
+			f.Terms = append(f.Terms, ClosedFormTerm{root.Value, poly.trim()})
+		}
+	}
+	return f, true
+}
+
+// ratPow is x^n for n >= 0, with 0^0 = 1.
+func ratPow(x *Rat, n int) *Rat {
+	num := new(Int).Exp(x.Num(), NewInt(int64(n)), nil)
+	den := new(Int).Exp(x.Denom(), NewInt(int64(n)), nil)
+	return new(Rat).SetFrac(num, den)
+}
+
+// Eval computes a(n) from the closed form. It fails for n below From.
+func (f ClosedForm) Eval(n int) (*Rat, bool) {
+	if n < f.From {
+		return nil, false
+	}
+	sum := new(Rat)
+	for _, term := range f.Terms {
+		value := term.Poly.Eval(NewRat(int64(n), 1))
+		sum.Add(sum, value.Mul(value, ratPow(term.Base, n)))
+	}
+	return sum, true
+}
+
+func (f ClosedForm) String() string {
+	if len(f.Terms) == 0 {
+		return "0"
+	}
+	terms := make([]string, len(f.Terms))
+	for i, term := range f.Terms {
+		terms[i] = fmt.Sprintf("(%s)(%s)^n", term.Poly.format("n"), term.Base.RatString())
+	}
+	return strings.Join(terms, " + ")
+}
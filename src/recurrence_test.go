@@ -0,0 +1,97 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func rats(vals ...int64) []*Rat {
+	return NewVector(vals...)
+}
+
+func TestRecurrenceTermMatchesIteration(t *testing.T) {
+	r, success := NewRecurrence([]*Rat{NewRat(1, 2), NewRat(1, 3), NewRat(-1, 1)}, rats(1, 0, 2))
+	if !success {
+		t.Fatal("NewRecurrence failed")
+	}
+	terms := append([]*Rat{}, r.Initial...)
+	for n := 3; n < 20; n++ {
+		next := new(Rat)
+		for i, c := range r.Coefficients {
+			next.Add(next, new(Rat).Mul(c, terms[n-1-i]))
+		}
+		terms = append(terms, next)
+	}
+	for n, expected := range terms {
+		actual, _ := r.Term(n)
+		Fail(t).If(rationalsAreNotEqual(expected, actual))
+	}
+}
+
+func TestFibonacciTerm(t *testing.T) {
+	r, _ := NewRecurrence(rats(1, 1), rats(0, 1))
+	f, _ := r.Term(100)
+	expected, _ := new(Int).SetString("354224848179261915075", 10)
+	if f.Num().Cmp(expected) != 0 {
+		t.Errorf("Expected %v; Actual %v", expected, f)
+	}
+	back, success := r.Term(-6)
+	if !success {
+		t.Fatal("Fibonacci can run backwards")
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(-8, 1), back))
+	if _, success := r.ClosedForm(); success {
+		t.Error("Fibonacci has irrational roots")
+	}
+}
+
+func TestClosedFormWithDistinctRoots(t *testing.T) {
+	// a(n) = 3a(n-1) - 2a(n-2), a(0) = 0, a(1) = 1 gives 2^n - 1.
+	r, _ := NewRecurrence(rats(3, -2), rats(0, 1))
+	f, success := r.ClosedForm()
+	if !success {
+		t.Fatal("ClosedForm failed")
+	}
+	if s := f.String(); s != "(-1)(1)^n + (1)(2)^n" {
+		t.Errorf("Actual %s", s)
+	}
+	for n := 0; n < 10; n++ {
+		expected, _ := r.Term(n)
+		actual, _ := f.Eval(n)
+		Fail(t).If(rationalsAreNotEqual(expected, actual))
+	}
+}
+
+func TestClosedFormWithRepeatedRoot(t *testing.T) {
+	// a(n) = 4a(n-1) - 4a(n-2), a(0) = 1, a(1) = 4 gives (n + 1)2^n.
+	r, _ := NewRecurrence(rats(4, -4), rats(1, 4))
+	f, _ := r.ClosedForm()
+	if len(f.Terms) != 1 || !f.Terms[0].Poly.Equals(NewPolynomial(1, 1)) {
+		t.Errorf("Expected (n + 1)(2)^n; Actual %v", f)
+	}
+}
+
+func TestClosedFormWithZeroRoot(t *testing.T) {
+	// a(n) = a(n-1) + 0a(n-2) from a(0) = 5, a(1) = 2 is constant from n = 1.
+	r, _ := NewRecurrence(rats(1, 0), rats(5, 2))
+	f, success := r.ClosedForm()
+	if !success {
+		t.Fatal("ClosedForm failed")
+	}
+	Fail(t).If(intsAreNotEqual(1, f.From))
+	value, _ := f.Eval(7)
+	Fail(t).If(rationalsAreNotEqual(NewRat(2, 1), value))
+	if _, success := f.Eval(0); success {
+		t.Error("Closed form does not hold before From")
+	}
+	if _, success := r.Term(-1); success {
+		t.Error("Cannot run backwards when c(k) is zero")
+	}
+}
+
+func TestNewRecurrenceNeedsMatchingLengths(t *testing.T) {
+	if _, success := NewRecurrence(rats(1, 1), rats(0)); success {
+		t.Error("NewRecurrence should fail")
+	}
+}
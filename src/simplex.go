@@ -0,0 +1,231 @@
+/*
+	Exact linear programming by the simplex method.
+*/
+package linear
+
+import . "big"
+
+// LPStatus is the outcome of solving a linear program.
+type LPStatus int
+
+const (
+	Optimal LPStatus = iota
+	Infeasible
+	Unbounded
+)
+
+func (s LPStatus) String() string {
+	switch s {
+	case Optimal:
+		return "optimal"
+	case Infeasible:
+		return "infeasible"
+	}
+	return "unbounded"
+}
+
+// LPSolution is the exact result of a linear program, with a certificate
+// that can be checked independently whatever the outcome.
+type LPSolution struct {
+	Status LPStatus
+	// Value is the optimal objective value, when Status is Optimal.
+	Value *Rat
+	// X is an optimal solution, or when Status is Unbounded a feasible one.
+	X Vector
+	// Y is an optimal solution of the dual program when Status is Optimal,
+	// with b'Y equal to Value. When Status is Infeasible it is a Farkas
+	// certificate: A'Y >= 0 and b'Y < 0, which no feasible x could satisfy.
+	Y Vector
+	// Ray is a direction along which the objective grows without limit from
+	// X while staying feasible, when Status is Unbounded.
+	Ray Vector
+}
+
+// Simplex maximizes c'x subject to a*x = b and x >= 0, exactly. The dual
+// program is to minimize b'y subject to a'y >= c. The first phase finds a
+// feasible basis by minimizing the sum of one artificial variable per
+// constraint, and the second optimizes c from there; both choose pivots by
+// Bland's rule, so they cannot cycle. To minimize, negate c.
+func Simplex(a Matrix, b, c Vector) (LPSolution, bool) {
+	if a.IsDegenerate() || a.IsEmpty() || len(b) != a.rows || len(c) != a.cols {
+		return LPSolution{}, false
+	}
+	m, n := a.rows, a.cols
+	rhs := n + m
+
+	// The tableau has the constraints [a | I | b], with rows negated where b
+	// is negative, above the objective row.
+	t := ZeroMatrix(m+1, n+m+1)
+	flipped := make([]bool, m)
+	for i := 0; i < m; i++ {
+		flipped[i] = b[i].Sign() < 0
+		for j := 0; j < n; j++ {
+			t.data[i][j].Set(cellOrZero(a.data[i][j]))
+		}
+		t.data[i][n+i].SetInt64(1)
+		t.data[i][rhs].Set(b[i])
+		if flipped[i] {
+			for j := 0; j < n; j++ {
+				t.data[i][j].Neg(t.data[i][j])
+			}
+			t.data[i][rhs].Neg(t.data[i][rhs])
+		}
+	}
+	basis := make([]int, m)
+	for i := range basis {
+		basis[i] = n + i
+	}
+
+	// Phase one maximizes minus the sum of the artificial variables.
+	for j := n; j < n+m; j++ {
+		t.data[m][j].SetInt64(1)
+	}
+	for i := 0; i < m; i++ {
+		t.eliminateColumn(i, n+i)
+	}
+	t.simplex(basis, n+m)
+
+	if t.data[m][rhs].Sign() < 0 {
+		// The phase one duals, read from the artificial columns whose cost
+		// was -1, prove infeasibility.
+		y := make(Vector, m)
+		for i := range y {
+			y[i] = new(Rat).Sub(t.data[m][n+i], NewRat(1, 1))
+		}
+		return LPSolution{Status: Infeasible, Y: unflip(y, flipped)}, true
+	}
+
+	// Drive any artificial variables left in the basis, all at zero, out of
+	// it. A row where that is impossible is redundant and is left alone.
+	for r, v := range basis {
+		if v < n {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if t.data[r][j].Sign() != 0 {
+				t.pivot(basis, r, j)
+				break
+			}
+		}
+	}
+
+	// Phase two starts from the objective -c, expressed in terms of the nonbasic variables.
+	for j := range t.data[m] {
+		t.data[m][j] = new(Rat)
+	}
+	for j := 0; j < n; j++ {
+		t.data[m][j].Neg(c[j])
+	}
+	for r, v := range basis {
+		t.eliminateColumn(r, v)
+	}
+	if entering, unbounded := t.simplex(basis, n); unbounded {
+		ray := ZeroVector(n)
+		ray[entering] = NewRat(1, 1)
+		for r, v := range basis {
+			if v < n {
+				ray[v] = new(Rat).Neg(t.data[r][entering])
+			}
+		}
+		return LPSolution{Status: Unbounded, X: t.basicSolution(basis, n), Ray: ray}, true
+	}
+
+	// With zero cost on the artificial columns, their reduced costs are the duals.
+	y := make(Vector, m)
+	for i := range y {
+		y[i] = new(Rat).Set(t.data[m][n+i])
+	}
+	return LPSolution{
+		Status: Optimal,
+		Value:  new(Rat).Set(t.data[m][rhs]),
+		X:      t.basicSolution(basis, n),
+		Y:      unflip(y, flipped),
+	}, true
+}
+
+// SimplexInequalities maximizes c'x subject to a*x <= b and x >= 0, by
+// adding a slack variable to each constraint. The dual program is to
+// minimize b'y subject to a'y >= c and y >= 0.
+func SimplexInequalities(a Matrix, b, c Vector) (LPSolution, bool) {
+	if a.IsDegenerate() || a.IsEmpty() || len(c) != a.cols {
+		return LPSolution{}, false
+	}
+	slack, _ := HStack(a, IdentityMatrix(a.rows))
+	solution, ok := Simplex(slack, b, append(append(Vector{}, c...), ZeroVector(a.rows)...))
+	if !ok {
+		return LPSolution{}, false
+	}
+	if solution.X != nil {
+		solution.X = solution.X[:a.cols]
+	}
+	if solution.Ray != nil {
+		solution.Ray = solution.Ray[:a.cols]
+	}
+	return solution, true
+}
+
+// simplex pivots the tableau to optimality by Bland's rule, letting only
+// the first `columns` variables enter the basis. The objective is the last
+// row, holding reduced costs, with its value in the last column. If the
+// objective is unbounded it stops and reports the column which could
+// increase forever.
+func (m Matrix) simplex(basis []int, columns int) (entering int, unbounded bool) {
+	obj, rhs := m.rows-1, m.cols-1
+	for {
+		// Bland's rule: the lowest numbered improving column enters...
+		entering = -1
+		for j := 0; j < columns; j++ {
+			if m.data[obj][j].Sign() < 0 {
+				entering = j
+				break
+			}
+		}
+		if entering < 0 {
+			return 0, false
+		}
+		// ...and of the rows limiting it most, the one whose basic variable is lowest numbered leaves.
+		leaving := -1
+		var best *Rat
+		for r := 0; r < obj; r++ {
+			if m.data[r][entering].Sign() <= 0 {
+				continue
+			}
+			ratio := new(Rat).Quo(m.data[r][rhs], m.data[r][entering])
+			if leaving < 0 || ratio.Cmp(best) < 0 || ratio.Cmp(best) == 0 && basis[r] < basis[leaving] {
+				leaving, best = r, ratio
+			}
+		}
+		if leaving < 0 {
+			return entering, true
+		}
+		m.pivot(basis, leaving, entering)
+	}
+}
+
+// pivot makes variable j basic in row r.
+func (m Matrix) pivot(basis []int, r, j int) {
+	m.normalizeRow(r, j)
+	m.eliminateColumn(r, j)
+	basis[r] = j
+}
+
+// basicSolution reads the values of the first n variables off the tableau.
+func (m Matrix) basicSolution(basis []int, n int) Vector {
+	x := ZeroVector(n)
+	for r, v := range basis {
+		if v < n {
+			x[v] = new(Rat).Set(m.data[r][m.cols-1])
+		}
+	}
+	return x
+}
+
+// unflip negates the entries of y for the constraints which were negated to make b nonnegative.
+func unflip(y Vector, flipped []bool) Vector {
+	for i, f := range flipped {
+		if f {
+			y[i].Neg(y[i])
+		}
+	}
+	return y
+}
@@ -0,0 +1,166 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func assertDot(t *testing.T, expected *Rat, v, w Vector) {
+	dot, _ := v.Dot(w)
+	Fail(t).If(rationalsAreNotEqual(expected, dot))
+}
+
+func TestSimplexInequalitiesOptimal(t *testing.T) {
+	// maximize 3x + 5y subject to x <= 4, 2y <= 12, 3x + 2y <= 18.
+	a := MakeMatrix(3, 2)
+	a.AddRow(1, 0)
+	a.AddRow(0, 2)
+	a.AddRow(3, 2)
+	b, c := NewVector(4, 12, 18), NewVector(3, 5)
+	solution, success := SimplexInequalities(a, b, c)
+	if !success || solution.Status != Optimal {
+		t.Fatalf("Expected an optimal solution; Actual %v", solution.Status)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(36, 1), solution.Value))
+	if !solution.X.Equals(NewVector(2, 6)) {
+		t.Errorf("Expected x = [2 6]; Actual %v", solution.X)
+	}
+	if !solution.Y.Equals(Vector{new(Rat), NewRat(3, 2), NewRat(1, 1)}) {
+		t.Errorf("Expected y = [0 3/2 1]; Actual %v", solution.Y)
+	}
+	assertDot(t, solution.Value, b, solution.Y)
+}
+
+func TestSimplexTreatsUnsetCellsAsZero(t *testing.T) {
+	// The problem above with explicit slack variables, leaving the zeros unset.
+	a := MakeMatrix(3, 5)
+	a.AddRow(1, 0, 1)
+	a.AddRow(0, 2, 0, 1)
+	a.AddRow(3, 2, 0, 0, 1)
+	solution, success := Simplex(a, NewVector(4, 12, 18), NewVector(3, 5, 0, 0, 0))
+	if !success || solution.Status != Optimal {
+		t.Fatalf("Expected an optimal solution; Actual %v", solution.Status)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(36, 1), solution.Value))
+	if !solution.X.Equals(NewVector(2, 6, 2, 0, 0)) {
+		t.Errorf("Expected x = [2 6 2 0 0]; Actual %v", solution.X)
+	}
+}
+
+func TestSimplexWithEqualitiesAndNegativeRightHandSide(t *testing.T) {
+	// minimize x1 + x2 + x3 subject to x1 - x2 = -1 and x2 + 2x3 = 3.
+	a := MakeMatrix(2, 3)
+	a.AddRow(1, -1, 0)
+	a.AddRow(0, 1, 2)
+	b, c := NewVector(-1, 3), NewVector(-1, -1, -1)
+	solution, success := Simplex(a, b, c)
+	if !success || solution.Status != Optimal {
+		t.Fatalf("Expected an optimal solution; Actual %v", solution.Status)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(-2, 1), solution.Value))
+	ax, _ := a.Multiply(solution.X.ColMatrix())
+	if !ax.Equals(b.ColMatrix()) {
+		t.Errorf("x = %v is not feasible", solution.X)
+	}
+	assertDot(t, solution.Value, c, solution.X)
+	assertDot(t, solution.Value, b, solution.Y)
+	// Dual feasibility: a'y >= c.
+	aty, _ := a.Transpose().Multiply(solution.Y.ColMatrix())
+	for j := range c {
+		if aty.Cell(j, 0).Cmp(c[j]) < 0 {
+			t.Errorf("Dual constraint %d is violated", j)
+		}
+	}
+}
+
+func TestSimplexDetectsInfeasibility(t *testing.T) {
+	// x + y <= 1 and -x - y <= -3 cannot both hold.
+	a := MakeMatrix(2, 2)
+	a.AddRow(1, 1)
+	a.AddRow(-1, -1)
+	b := NewVector(1, -3)
+	solution, success := SimplexInequalities(a, b, NewVector(1, 1))
+	if !success || solution.Status != Infeasible {
+		t.Fatalf("Expected infeasible; Actual %v", solution.Status)
+	}
+	y := solution.Y
+	for _, v := range y {
+		if v.Sign() < 0 {
+			t.Errorf("Certificate %v should be nonnegative", y)
+		}
+	}
+	aty, _ := a.Transpose().Multiply(y.ColMatrix())
+	for j := 0; j < 2; j++ {
+		if aty.Cell(j, 0).Sign() < 0 {
+			t.Errorf("a'y = %v should be nonnegative", aty)
+		}
+	}
+	if dot, _ := b.Dot(y); dot.Sign() >= 0 {
+		t.Errorf("b'y = %v should be negative", dot)
+	}
+}
+
+func TestSimplexDetectsUnboundedness(t *testing.T) {
+	// maximize x + y subject to x - y <= 1.
+	a := MakeMatrix(1, 2)
+	a.AddRow(1, -1)
+	c := NewVector(1, 1)
+	solution, success := SimplexInequalities(a, NewVector(1), c)
+	if !success || solution.Status != Unbounded {
+		t.Fatalf("Expected unbounded; Actual %v", solution.Status)
+	}
+	ad, _ := a.Multiply(solution.Ray.ColMatrix())
+	if ad.Cell(0, 0).Sign() > 0 {
+		t.Errorf("Ray %v leaves the feasible region", solution.Ray)
+	}
+	if dot, _ := c.Dot(solution.Ray); dot.Sign() <= 0 {
+		t.Errorf("Objective should grow along the ray %v", solution.Ray)
+	}
+	ax, _ := a.Multiply(solution.X.ColMatrix())
+	if ax.Cell(0, 0).Cmp(NewRat(1, 1)) > 0 {
+		t.Errorf("x = %v is not feasible", solution.X)
+	}
+}
+
+func TestSimplexHandlesRedundantConstraints(t *testing.T) {
+	// The second equation is twice the first.
+	a := MakeMatrix(2, 2)
+	a.AddRow(1, 1)
+	a.AddRow(2, 2)
+	solution, success := Simplex(a, NewVector(4, 8), NewVector(1, 2))
+	if !success || solution.Status != Optimal {
+		t.Fatalf("Expected an optimal solution; Actual %v", solution.Status)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(8, 1), solution.Value))
+	if !solution.X.Equals(NewVector(0, 4)) {
+		t.Errorf("Expected x = [0 4]; Actual %v", solution.X)
+	}
+	assertDot(t, solution.Value, NewVector(4, 8), solution.Y)
+}
+
+func TestSimplexTerminatesOnDegenerateProblem(t *testing.T) {
+	// Beale's example, which cycles under the textbook largest coefficient rule.
+	a := MakeMatrix(3, 4)
+	a.SetCell(0, 0, NewRat(1, 4))
+	a.SetCell(0, 1, -60)
+	a.SetCell(0, 2, NewRat(-1, 25))
+	a.SetCell(0, 3, 9)
+	a.SetCell(1, 0, NewRat(1, 2))
+	a.SetCell(1, 1, -90)
+	a.SetCell(1, 2, NewRat(-1, 50))
+	a.SetCell(1, 3, 3)
+	a.AddRow(0, 0, 1, 0)
+	c := Vector{NewRat(3, 4), NewRat(-150, 1), NewRat(1, 50), NewRat(-6, 1)}
+	solution, success := SimplexInequalities(a, NewVector(0, 0, 1), c)
+	if !success || solution.Status != Optimal {
+		t.Fatalf("Expected an optimal solution; Actual %v", solution.Status)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 20), solution.Value))
+}
+
+func TestSimplexRejectsMismatchedDimensions(t *testing.T) {
+	if _, success := Simplex(nonZeroMatrix(2, 3), NewVector(1), NewVector(1, 1, 1)); success {
+		t.Error("Simplex should fail")
+	}
+}
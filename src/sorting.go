@@ -0,0 +1,112 @@
+/*
+	Reordering the rows and columns of a matrix.
+*/
+package linear
+
+import (
+	"sort"
+)
+
+// The sorting methods reorder the matrix in place and return the Permutation
+// they applied: entry i is the original index of the row (or column) which
+// now sits at position i. Passing it to UnsortRows (or UnsortCols) undoes the sort.
+
+// SortRowsBy orders the rows so that cmp(a, b) <= 0 for each row a preceding a row b.
+// cmp returns a negative number when a should come first, and a positive one when b should.
+func (m Matrix) SortRowsBy(cmp func(a, b MatrixRow) int) Permutation {
+	return m.sortRows(cmp, false)
+}
+
+// SortRowsStableBy is SortRowsBy, keeping rows which compare equal in their original order.
+func (m Matrix) SortRowsStableBy(cmp func(a, b MatrixRow) int) Permutation {
+	return m.sortRows(cmp, true)
+}
+
+// SortColsBy orders the columns so that cmp(a, b) <= 0 for each column a preceding a column b.
+// A degenerate matrix is left as it is, and the permutation is nil.
+func (m Matrix) SortColsBy(cmp func(a, b MatrixRow) int) Permutation {
+	return m.sortCols(cmp, false)
+}
+
+// SortColsStableBy is SortColsBy, keeping columns which compare equal in their original order.
+func (m Matrix) SortColsStableBy(cmp func(a, b MatrixRow) int) Permutation {
+	return m.sortCols(cmp, true)
+}
+
+// SortByColumn orders the rows by their values in column j, smallest first,
+// keeping rows with equal values in their original order.
+func (m Matrix) SortByColumn(j int) (Permutation, bool) {
+	if m.IsDegenerate() || j < 0 || j >= m.cols {
+		return nil, false
+	}
+	return m.SortRowsStableBy(func(a, b MatrixRow) int {
+		return compareCells(a[j], b[j])
+	}), true
+}
+
+// UnsortRows puts the rows back where they were before the sort which returned perm.
+// It fails if perm is not a permutation of the rows.
+func (m Matrix) UnsortRows(perm Permutation) bool {
+	inverse, ok := perm.Inverse()
+	if !ok {
+		return false
+	}
+	original, ok := inverse.ApplyRows(m)
+	if !ok {
+		return false
+	}
+	copy(m.data, original.data)
+	return true
+}
+
+// UnsortCols puts the columns back where they were before the sort which returned perm.
+// It fails if perm is not a permutation of the columns.
+func (m Matrix) UnsortCols(perm Permutation) bool {
+	inverse, ok := perm.Inverse()
+	if !ok {
+		return false
+	}
+	original, ok := inverse.ApplyCols(m)
+	if !ok {
+		return false
+	}
+	copy(m.data, original.data)
+	return true
+}
+
+func sortPermutation(perm Permutation, less func(a, b int) bool, stable bool) {
+	if stable {
+		sort.SliceStable(perm, less)
+	} else {
+		sort.Slice(perm, less)
+	}
+}
+
+func (m Matrix) sortRows(cmp func(a, b MatrixRow) int, stable bool) Permutation {
+	perm := IdentityPermutation(m.rows)
+	sortPermutation(perm, func(a, b int) bool {
+		return cmp(m.data[perm[a]], m.data[perm[b]]) < 0
+	}, stable)
+
+	sorted, _ := perm.ApplyRows(m)
+	copy(m.data, sorted.data)
+	return perm
+}
+
+func (m Matrix) sortCols(cmp func(a, b MatrixRow) int, stable bool) Permutation {
+	if m.IsDegenerate() {
+		return nil
+	}
+	cols := make([]MatrixRow, m.cols)
+	for j := range cols {
+		cols[j] = m.getCol(j)
+	}
+	perm := IdentityPermutation(m.cols)
+	sortPermutation(perm, func(a, b int) bool {
+		return cmp(cols[perm[a]], cols[perm[b]]) < 0
+	}, stable)
+
+	sorted, _ := perm.ApplyCols(m)
+	copy(m.data, sorted.data)
+	return perm
+}
@@ -0,0 +1,116 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func byFirstCell(a, b MatrixRow) int {
+	return a[0].Cmp(b[0])
+}
+
+func TestLessIsFalseForEqualRows(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(1, 2)
+	if m.Less(0, 1) || m.Less(1, 0) {
+		t.Fail()
+	}
+}
+
+func TestLessTreatsUnsetCellsAsZero(t *testing.T) {
+	m := ZeroMatrix(2, 2)
+	m.data[0][0] = nil
+	m.SetCell(1, 0, -1)
+	if !m.Less(0, 1) || m.Less(1, 0) {
+		t.Fail()
+	}
+}
+
+func TestSortRowsByReturnsPermutation(t *testing.T) {
+	m := MakeMatrix(3, 2)
+	m.AddRow(3, 0)
+	m.AddRow(1, 1)
+	m.AddRow(2, 2)
+	perm := m.SortRowsBy(byFirstCell)
+	if perm[0] != 1 || perm[1] != 2 || perm[2] != 0 {
+		t.Errorf("Expected [1 2 0]; Actual %v", perm)
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 1), m.data[0][0]))
+}
+
+func TestUnsortRowsRestoresOriginalOrder(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	perm := m.SortRowsBy(func(a, b MatrixRow) int { return b[0].Cmp(a[0]) })
+	if m.Equals(nonZeroMatrix4x4()) {
+		t.Error("Sorting should have changed the order")
+	}
+	m.UnsortRows(perm)
+	if !m.Equals(nonZeroMatrix4x4()) {
+		t.Error("Unsorting should have restored the order")
+	}
+}
+
+func TestUnsortWithInvalidPermutationFails(t *testing.T) {
+	m := nonZeroMatrix4x4()
+	for _, perm := range []Permutation{{0, 1, 2}, {0, 1, 1, 3}, {0, 1, 2, 4}} {
+		if m.UnsortRows(perm) || m.UnsortCols(perm) {
+			t.Errorf("Unsorting with %v should fail", perm)
+		}
+	}
+	if !m.Equals(nonZeroMatrix4x4()) {
+		t.Error("A failed unsort should leave the matrix untouched")
+	}
+}
+
+func TestSortRowsStableByKeepsEqualRowsInOrder(t *testing.T) {
+	m := MakeMatrix(4, 2)
+	m.AddRow(1, 0)
+	m.AddRow(0, 1)
+	m.AddRow(1, 2)
+	m.AddRow(0, 3)
+	perm := m.SortRowsStableBy(byFirstCell)
+	if perm[0] != 1 || perm[1] != 3 || perm[2] != 0 || perm[3] != 2 {
+		t.Errorf("Expected [1 3 0 2]; Actual %v", perm)
+	}
+}
+
+func TestSortColsByAndUnsortCols(t *testing.T) {
+	m := MakeMatrix(2, 3)
+	m.AddRow(3, 1, 2)
+	m.AddRow(6, 4, 5)
+	perm := m.SortColsBy(byFirstCell)
+
+	expected := MakeMatrix(2, 3)
+	expected.AddRow(1, 2, 3)
+	expected.AddRow(4, 5, 6)
+	if !m.Equals(expected) {
+		t.Error("Columns are not sorted")
+	}
+
+	m.UnsortCols(perm)
+	original := MakeMatrix(2, 3)
+	original.AddRow(3, 1, 2)
+	original.AddRow(6, 4, 5)
+	if !m.Equals(original) {
+		t.Error("Unsorting should have restored the order")
+	}
+}
+
+func TestSortByColumn(t *testing.T) {
+	m := MakeMatrix(3, 2)
+	m.AddRow(0, 5)
+	m.AddRow(1, -2)
+	m.AddRow(2, 5)
+	perm, success := m.SortByColumn(1)
+	if !success || perm[0] != 1 || perm[1] != 0 || perm[2] != 2 {
+		t.Errorf("Expected [1 0 2]; Actual %v", perm)
+	}
+}
+
+func TestSortByColumnOutOfRangeFails(t *testing.T) {
+	if _, success := unitMatrix(2).SortByColumn(2); success {
+		t.Fail()
+	}
+}
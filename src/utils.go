@@ -11,12 +11,7 @@ import . "big"
 
 // Create an nXn matrix with '1' on the diagonal, and zeros otherwise.
 func unitMatrix(rows int) Matrix {
-	cols := rows
-	m := ZeroMatrix(rows, cols)
-	for i := 0; i < rows; i++ {
-		m.SetCell(i, i, 1)
-	}
-	return m
+	return IdentityMatrix(rows)
 }
 
 func (m Matrix) getRow(index int) MatrixRow {
@@ -47,6 +42,10 @@ func valueToRational(v reflect.Value) (rational *Rat, success bool) {
 		rational, success = NewRat(int64(i.Int()), 1), true
 	case reflect.Interface:
 		rational, success = valueToRational(i.Elem())
+	case reflect.Ptr:
+		if r, ok := i.Interface().(*Rat); ok && r != nil {
+			rational, success = new(Rat).Set(r), true
+		}
 	}
 	return
 }
@@ -121,13 +120,7 @@ func (v MatrixRow) multiply(v2 MatrixRow) (result MatrixRow) {
 	}
 	result = make(MatrixRow, len(v))
 	for i := 0; i < len(v); i++ {
-		if v[i] == nil {
-			v[i] = NewRat(0, 1)
-		}
-		if v2[i] == nil {
-			v2[i] = NewRat(0, 1)
-		}
-		result[i] = new(Rat).Mul(v[i], v2[i])
+		result[i] = new(Rat).Mul(cellOrZero(v[i]), cellOrZero(v2[i]))
 	}
 	return
 }
@@ -135,11 +128,8 @@ func (v MatrixRow) multiply(v2 MatrixRow) (result MatrixRow) {
 func (v MatrixRow) sumAll() *Rat{
 	sum := NewRat(0, 1)
 	for _, r := range v {
-		sum = sum.Add(sum, r)
+		sum = sum.Add(sum, cellOrZero(r))
 	}
 	return sum
 }
 
-func sum(a interface{}, b interface{}) interface{} {
-	return new(Rat).Add(a.(*Rat), b.(*Rat))
-}
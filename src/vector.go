@@ -0,0 +1,179 @@
+/*
+	Vectors of rationals and their geometry.
+*/
+package linear
+
+import . "big"
+
+// Vector is a list of rationals. Unlike MatrixRow, its operations never
+// change their operands, and unset entries are read as zero.
+type Vector []*Rat
+
+// NewVector creates a vector with the given integer values.
+func NewVector(vals ...int64) Vector {
+	return Vector(createRow(len(vals), vals...))
+}
+
+// ZeroVector creates a vector of n zeros.
+func ZeroVector(n int) Vector {
+	return NewVector(make([]int64, n)...)
+}
+
+// Row copies out row i of the matrix as a vector. Unset cells come out as zero.
+func (m Matrix) Row(i int) (Vector, bool) {
+	if i < 0 || i >= m.rows || len(m.data[i]) != m.cols {
+		return nil, false
+	}
+	v := make(Vector, m.cols)
+	copyCells(MatrixRow(v), m.data[i])
+	return v, true
+}
+
+// Col copies out column j of the matrix as a vector. Unset cells come out as zero.
+func (m Matrix) Col(j int) (Vector, bool) {
+	if j < 0 || j >= m.cols || m.IsDegenerate() {
+		return nil, false
+	}
+	v := make(Vector, m.rows)
+	for i, row := range m.data {
+		v[i] = new(Rat).Set(cellOrZero(row[j]))
+	}
+	return v, true
+}
+
+// Len is the number of entries in the vector.
+func (v Vector) Len() int {
+	return len(v)
+}
+
+// Equals if the vectors have the same length and values.
+func (v Vector) Equals(w Vector) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i := range v {
+		if compareCells(v[i], w[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero if every entry of the vector is zero.
+func (v Vector) IsZero() bool {
+	for _, x := range v {
+		if cellOrZero(x).Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Dot is the sum of the products of corresponding entries.
+func (v Vector) Dot(w Vector) (*Rat, bool) {
+	if len(v) != len(w) {
+		return nil, false
+	}
+	return MatrixRow(v).multiply(MatrixRow(w)).sumAll(), true
+}
+
+// Cross is the cross product of two vectors with three entries each.
+func (v Vector) Cross(w Vector) (Vector, bool) {
+	if len(v) != 3 || len(w) != 3 {
+		return nil, false
+	}
+	product := func(i, j int) *Rat {
+		a := new(Rat).Mul(cellOrZero(v[i]), cellOrZero(w[j]))
+		b := new(Rat).Mul(cellOrZero(v[j]), cellOrZero(w[i]))
+		return a.Sub(a, b)
+	}
+	return Vector{product(1, 2), product(2, 0), product(0, 1)}, true
+}
+
+// Norm1 is the sum of the absolute values of the entries.
+func (v Vector) Norm1() *Rat {
+	sum := new(Rat)
+	for _, x := range v {
+		sum.Add(sum, new(Rat).Abs(cellOrZero(x)))
+	}
+	return sum
+}
+
+// NormInf is the largest absolute value among the entries.
+func (v Vector) NormInf() *Rat {
+	max := new(Rat)
+	for _, x := range v {
+		if abs := new(Rat).Abs(cellOrZero(x)); abs.Cmp(max) > 0 {
+			max = abs
+		}
+	}
+	return max
+}
+
+// Norm2Squared is the square of the Euclidean length, which unlike the
+// length itself is always rational.
+func (v Vector) Norm2Squared() *Rat {
+	sq, _ := v.Dot(v)
+	return sq
+}
+
+// Scale multiplies every entry by k.
+func (v Vector) Scale(k *Rat) Vector {
+	result := make(Vector, len(v))
+	for i, x := range v {
+		result[i] = new(Rat).Mul(cellOrZero(x), k)
+	}
+	return result
+}
+
+// Add the given vector to another vector.
+func (v Vector) Add(w Vector) (Vector, bool) {
+	if len(v) != len(w) {
+		return nil, false
+	}
+	result := make(Vector, len(v))
+	for i := range v {
+		result[i] = new(Rat).Add(cellOrZero(v[i]), cellOrZero(w[i]))
+	}
+	return result, true
+}
+
+// Sub subtracts the given vector from another vector.
+func (v Vector) Sub(w Vector) (Vector, bool) {
+	if len(v) != len(w) {
+		return nil, false
+	}
+	return v.Add(w.Scale(NewRat(-1, 1)))
+}
+
+// Project finds the component of v in the direction of onto, which must not be zero.
+func (v Vector) Project(onto Vector) (Vector, bool) {
+	if len(v) != len(onto) || onto.IsZero() {
+		return nil, false
+	}
+	dot, _ := v.Dot(onto)
+	return onto.Scale(dot.Quo(dot, onto.Norm2Squared())), true
+}
+
+// AngleCos2 is the square of the cosine of the angle between two nonzero
+// vectors, which is rational where the cosine itself may not be.
+func (v Vector) AngleCos2(w Vector) (*Rat, bool) {
+	if len(v) != len(w) || v.IsZero() || w.IsZero() {
+		return nil, false
+	}
+	dot, _ := v.Dot(w)
+	cos2 := new(Rat).Mul(dot, dot)
+	return cos2.Quo(cos2, new(Rat).Mul(v.Norm2Squared(), w.Norm2Squared())), true
+}
+
+// RowMatrix turns the vector into a matrix with a single row.
+func (v Vector) RowMatrix() Matrix {
+	m := MakeMatrix(1, len(v))
+	m.data[0] = MatrixRow(v.Scale(NewRat(1, 1)))
+	return m
+}
+
+// ColMatrix turns the vector into a matrix with a single column.
+func (v Vector) ColMatrix() Matrix {
+	return v.RowMatrix().Transpose()
+}
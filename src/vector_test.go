@@ -0,0 +1,112 @@
+package linear
+
+import (
+	"testing"
+)
+
+import . "big"
+
+func TestDotProduct(t *testing.T) {
+	dot, success := NewVector(1, 2, 3).Dot(NewVector(4, -5, 6))
+	if !success {
+		t.Fail()
+	}
+	Fail(t).If(rationalsAreNotEqual(NewRat(12, 1), dot))
+}
+
+func TestDotProductWithDifferentLengthsFails(t *testing.T) {
+	if _, success := NewVector(1, 2).Dot(NewVector(1, 2, 3)); success {
+		t.Fail()
+	}
+}
+
+func TestDotProductDoesNotChangeUnsetEntries(t *testing.T) {
+	v := Vector{nil, NewRat(1, 1)}
+	v.Dot(NewVector(1, 1))
+	if v[0] != nil {
+		t.Fail()
+	}
+}
+
+func TestCrossProductIsOrthogonal(t *testing.T) {
+	v, w := NewVector(1, 2, 3), NewVector(-2, 0, 5)
+	cross, success := v.Cross(w)
+	if !success || !cross.Equals(NewVector(10, -11, 4)) {
+		t.Errorf("Expected [10 -11 4]; Actual %v", cross)
+	}
+	dot, _ := cross.Dot(v)
+	Fail(t).If(rationalsAreNotEqual(NewRat(0, 1), dot))
+}
+
+func TestCrossProductNeedsThreeEntries(t *testing.T) {
+	if _, success := NewVector(1, 2).Cross(NewVector(3, 4)); success {
+		t.Fail()
+	}
+}
+
+func TestNorms(t *testing.T) {
+	v := NewVector(3, -4, 0)
+	Fail(t).If(rationalsAreNotEqual(NewRat(7, 1), v.Norm1()))
+	Fail(t).If(rationalsAreNotEqual(NewRat(4, 1), v.NormInf()))
+	Fail(t).If(rationalsAreNotEqual(NewRat(25, 1), v.Norm2Squared()))
+}
+
+func TestAddSubAndScale(t *testing.T) {
+	v, w := NewVector(1, 2), NewVector(3, 5)
+	sum, _ := v.Add(w)
+	diff, _ := sum.Sub(w)
+	if !sum.Equals(NewVector(4, 7)) || !diff.Equals(v) {
+		t.Fail()
+	}
+	if !v.Scale(NewRat(-2, 1)).Equals(NewVector(-2, -4)) {
+		t.Fail()
+	}
+}
+
+func TestProject(t *testing.T) {
+	p, success := NewVector(2, 3).Project(NewVector(1, 1))
+	half := NewRat(5, 2)
+	if !success || !p.Equals(Vector{half, half}) {
+		t.Errorf("Expected [5/2 5/2]; Actual %v", p)
+	}
+	if _, success := NewVector(2, 3).Project(ZeroVector(2)); success {
+		t.Error("Projection onto zero vector should fail")
+	}
+}
+
+func TestAngleCos2(t *testing.T) {
+	cos2, _ := NewVector(1, 0).AngleCos2(NewVector(1, 1))
+	Fail(t).If(rationalsAreNotEqual(NewRat(1, 2), cos2))
+	cos2, _ = NewVector(1, 0).AngleCos2(NewVector(0, 3))
+	Fail(t).If(rationalsAreNotEqual(NewRat(0, 1), cos2))
+}
+
+func TestRowAndColMatrix(t *testing.T) {
+	v := NewVector(1, 2, 3)
+	row, col := v.RowMatrix(), v.ColMatrix()
+	Fail(t).If(intsAreNotEqual(3, row.ColCount()))
+	Fail(t).If(intsAreNotEqual(3, col.RowCount()))
+	product, _ := row.Multiply(col)
+	Fail(t).If(rationalsAreNotEqual(NewRat(14, 1), product.data[0][0]))
+
+	back, _ := col.Col(0)
+	if !back.Equals(v) {
+		t.Fail()
+	}
+}
+
+func TestRowAndColAreCopies(t *testing.T) {
+	m := MakeMatrix(2, 2)
+	m.AddRow(1, 2)
+	m.AddRow(3)
+	row, _ := m.Row(1)
+	col, _ := m.Col(1)
+	if !row.Equals(NewVector(3, 0)) || !col.Equals(NewVector(2, 0)) {
+		t.Errorf("Expected [3 0] and [2 0]; Actual %v and %v", row, col)
+	}
+	row[0].SetInt64(9)
+	col[0].SetInt64(9)
+	if m.Cell(1, 0).Cmp(NewRat(3, 1)) != 0 || m.Cell(0, 1).Cmp(NewRat(2, 1)) != 0 {
+		t.Error("Changing the vectors changed the matrix")
+	}
+}